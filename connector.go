@@ -10,8 +10,20 @@ import (
 	"github.com/ydb-platform/ydb-go-sdk/v3/trace"
 
 	"github.com/ydb-platform/ydb-go-sql/internal/connector"
+	"github.com/ydb-platform/ydb-go-sql/internal/query"
+	"github.com/ydb-platform/ydb-go-sql/metrics"
+	sqltrace "github.com/ydb-platform/ydb-go-sql/trace"
 )
 
+// Query is a query passing through preprocessing pipeline which runs before
+// every query execution and statement preparing. Args are nil and Prepare is
+// true when query is being prepared.
+type Query = query.Query
+
+// QueryStage is a user stage of query preprocessing pipeline. Stage could
+// modify text and args of query or reject query with an error.
+type QueryStage = query.Stage
+
 func Connector(opts ...connector.Option) driver.Connector {
 	return connector.New(legacyDriver, opts...)
 }
@@ -63,3 +75,76 @@ func WithDefaultExecDataQueryOption(opts ...options.ExecuteDataQueryOption) conn
 func WithDefaultExecScanQueryOption(opts ...options.ExecuteScanQueryOption) connector.Option {
 	return connector.WithDefaultExecScanQueryOption(opts...)
 }
+
+// WithPositionalArgs enables positional query parameters: placeholders ? and
+// $N are rewritten into $pN named parameters, e.g.
+//
+//	db.QueryContext(ctx, "SELECT * FROM series WHERE series_id = ?", 1)
+//
+// executes query with parameter $p1. Placeholders within string literals,
+// quoted identifiers and comments are left as is. Parameters of prepared
+// statements are not declared automatically (see WithAutoDeclare), so query
+// passed to PrepareContext must declare $pN parameters itself:
+//
+//	db.PrepareContext(ctx, "DECLARE $p1 AS Uint64; SELECT * FROM series WHERE series_id = ?")
+func WithPositionalArgs() connector.Option {
+	return connector.WithPositionalArgs()
+}
+
+// WithAutoDeclare enables automatic declaration of query parameters: missing
+// DECLARE statements are prepended to query text by types of bound values.
+// Parameters already declared in query are left as is. Prepared statements
+// are not affected because types of args are unknown on preparing, so their
+// parameters must be declared in query text. See also WithAutoDeclareParams
+// for enabling it per query.
+func WithAutoDeclare() connector.Option {
+	return connector.WithAutoDeclare()
+}
+
+// WithValidateParams enables client-side check of query parameters before
+// execution of data and scan queries: every parameter declared in query with
+// DECLARE statement must be bound with value of declared type. Violations are
+// reported without round-trip to server with errors naming the parameter and
+// both types. Args of prepared statements are checked on every execution.
+func WithValidateParams() connector.Option {
+	return connector.WithValidateParams()
+}
+
+// WithTablePathPrefix prepends queries with PRAGMA TablePathPrefix(prefix)
+// unless query contains TablePathPrefix pragma already.
+func WithTablePathPrefix(prefix string) connector.Option {
+	return connector.WithTablePathPrefix(prefix)
+}
+
+// WithQueryTag prepends queries with comment containing tag, e.g. name of
+// application. Tags from context (see WithQueryTags) are appended to it.
+func WithQueryTag(tag string) connector.Option {
+	return connector.WithQueryTag(tag)
+}
+
+// WithQueryStage appends user stage to query preprocessing pipeline. Stages of
+// pipeline run in the following order: rewriting of positional placeholders
+// (see WithPositionalArgs), binding of args, user stages in order of options,
+// automatic declaration of parameters (see WithAutoDeclare), validation of
+// parameters (see WithValidateParams), prefixing with TablePathPrefix (see
+// WithTablePathPrefix) and tagging (see WithQueryTag).
+func WithQueryStage(stage QueryStage) connector.Option {
+	return connector.WithQueryStage(stage)
+}
+
+// WithTraceSQL appends t to traces of database/sql driver activity:
+// connecting, pinging and resetting of connections, preparing and execution
+// of queries and statements, transactions and iteration over rows. Multiple
+// traces are composed. Trace could be also attached to context with
+// trace.WithTrace from github.com/ydb-platform/ydb-go-sql/trace package.
+func WithTraceSQL(t sqltrace.Trace) connector.Option {
+	return connector.WithTraceSQL(t)
+}
+
+// WithMetrics enables collecting of driver metrics into r: latencies and
+// counters of queries by mode, transactions by outcome, retries of
+// transactions, errors by kind and number of open sessions. See
+// github.com/ydb-platform/ydb-go-sql/metrics package for list of metrics.
+func WithMetrics(r metrics.Registry) connector.Option {
+	return connector.WithTraceSQL(metrics.Trace(r))
+}
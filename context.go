@@ -3,7 +3,9 @@ package ydb
 import (
 	"context"
 
+	"github.com/ydb-platform/ydb-go-sdk/v3/retry"
 	"github.com/ydb-platform/ydb-go-sdk/v3/table"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/options"
 
 	"github.com/ydb-platform/ydb-go-sql/internal/mode"
 	"github.com/ydb-platform/ydb-go-sql/internal/x"
@@ -28,3 +30,53 @@ func WithExplain(ctx context.Context) context.Context {
 func WithTxControl(ctx context.Context, tx *table.TransactionControl) context.Context {
 	return x.WithTxControl(ctx, tx)
 }
+
+// WithDataQueryOptions returns a copy of parent context with data query options
+// for queries, statements and transactions executed with it. Options from
+// context applied after connector defaults (see WithDefaultExecDataQueryOption)
+// so they take precedence. Nested calls accumulate options.
+func WithDataQueryOptions(ctx context.Context, opts ...options.ExecuteDataQueryOption) context.Context {
+	return x.WithDataQueryOptions(ctx, opts)
+}
+
+// WithScanQueryOptions returns a copy of parent context with scan query options.
+// Options from context applied after connector defaults (see
+// WithDefaultExecScanQueryOption) so they take precedence. Nested calls
+// accumulate options.
+func WithScanQueryOptions(ctx context.Context, opts ...options.ExecuteScanQueryOption) context.Context {
+	return x.WithScanQueryOptions(ctx, opts)
+}
+
+// WithAutoDeclareParams returns a copy of parent context which enables
+// automatic declaration of parameters for queries executed with it (see
+// WithAutoDeclare). Statements prepared with it are not affected: parameters
+// of prepared statements must be declared in query text.
+func WithAutoDeclareParams(ctx context.Context) context.Context {
+	return x.WithAutoDeclare(ctx)
+}
+
+// WithQueryTags returns a copy of parent context with tags which are added to
+// comment of queries executed with it (see WithQueryTag). Nested calls
+// accumulate tags.
+func WithQueryTags(ctx context.Context, tags ...string) context.Context {
+	return x.WithQueryTags(ctx, tags)
+}
+
+// WithStats returns a copy of parent context which enables collecting of
+// statistics for data and scan queries executed with it, including queries
+// within transactions and prepared statements. Callback f is called with
+// statistics after execution of data query and on close of rows of scan
+// query. Statistics of scan query arrive at the end of stream, so they are
+// reported only if all result sets of rows are read. Statistics are collected
+// in basic mode unless more detailed mode is set with query options.
+func WithStats(ctx context.Context, f func(QueryStats)) context.Context {
+	return x.WithStats(ctx, f)
+}
+
+func WithIdempotentOperation(ctx context.Context) context.Context {
+	return retry.WithIdempotentOperation(ctx)
+}
+
+func WithNonIdempotentOperation(ctx context.Context) context.Context {
+	return retry.WithNonIdempotentOperation(ctx)
+}
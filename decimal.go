@@ -0,0 +1,24 @@
+package ydb
+
+import (
+	"math/big"
+
+	"github.com/ydb-platform/ydb-go-sql/internal/decimal"
+)
+
+// Decimal is a number of YDB Decimal(Precision,Scale) type. It could be
+// passed as query parameter and scanned from Decimal columns. Use *Decimal
+// for scanning of Optional<Decimal> columns.
+type Decimal = decimal.Decimal
+
+// DecimalFromString parses decimal number s, e.g. "-12.345", and returns
+// Decimal of it with given precision and scale.
+func DecimalFromString(s string, precision, scale uint32) (Decimal, error) {
+	return decimal.FromString(s, precision, scale)
+}
+
+// DecimalFromRat returns Decimal of r with given precision and scale. It
+// returns error if r could not be represented without rounding.
+func DecimalFromRat(r *big.Rat, precision, scale uint32) (Decimal, error) {
+	return decimal.FromRat(r, precision, scale)
+}
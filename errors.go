@@ -1,6 +1,16 @@
 package ydb
 
-import "github.com/ydb-platform/ydb-go-sdk/v3"
+import (
+	"github.com/ydb-platform/ydb-go-sdk/v3"
+
+	"github.com/ydb-platform/ydb-go-sql/internal/errors"
+)
+
+var (
+	ErrTxCommitted  = errors.ErrTxCommitted
+	ErrTxRolledBack = errors.ErrTxRolledBack
+	ErrTxBroken     = errors.ErrTxBroken
+)
 
 func IsTimeoutError(err error) bool {
 	return ydb.IsTimeoutError(err)
@@ -3,6 +3,7 @@ module github.com/ydb-platform/ydb-go-sql
 go 1.16
 
 require (
+	github.com/ydb-platform/ydb-go-genproto v0.0.0-20211103074319-526e57659e16
 	github.com/ydb-platform/ydb-go-sdk/v3 v3.5.2
 	google.golang.org/grpc v1.38.0
 	google.golang.org/protobuf v1.26.0
@@ -7,55 +7,26 @@ import (
 	"github.com/ydb-platform/ydb-go-sdk/v3/table/types"
 )
 
+// NamedValue converts value of v into types.Value and prefixes name of named
+// parameter with $. Positional parameters are left unnamed to be bound by
+// query.Querier.
 func NamedValue(v *driver.NamedValue) (err error) {
-	if v.Name == "" {
-		return fmt.Errorf("ydb: only named parameters are supported")
-	}
-
-	if valuer, ok := v.Value.(driver.Valuer); ok {
-		v.Value, err = valuer.Value()
-		if err != nil {
-			return fmt.Errorf("ydb: driver.Valuer error: %w", err)
+	v.Value, err = toValue(v.Value)
+	if err != nil {
+		if v.Name == "" {
+			return fmt.Errorf("%w (parameter #%d)", err, v.Ordinal)
 		}
+		return fmt.Errorf("%w (parameter $%s)", err, v.Name)
 	}
 
-	switch x := v.Value.(type) {
-	case types.Value:
-		// OK.
-	case bool:
-		v.Value = types.BoolValue(x)
-	case int8:
-		v.Value = types.Int8Value(x)
-	case uint8:
-		v.Value = types.Uint8Value(x)
-	case int16:
-		v.Value = types.Int16Value(x)
-	case uint16:
-		v.Value = types.Uint16Value(x)
-	case int32:
-		v.Value = types.Int32Value(x)
-	case uint32:
-		v.Value = types.Uint32Value(x)
-	case int64:
-		v.Value = types.Int64Value(x)
-	case uint64:
-		v.Value = types.Uint64Value(x)
-	case float32:
-		v.Value = types.FloatValue(x)
-	case float64:
-		v.Value = types.DoubleValue(x)
-	case []byte:
-		v.Value = types.StringValue(x)
-	case string:
-		v.Value = types.UTF8Value(x)
-	case [16]byte:
-		v.Value = types.UUIDValue(x)
-
-	default:
-		return fmt.Errorf("ydb: unsupported type: %T", x)
+	if v.Name != "" {
+		v.Name = "$" + v.Name
 	}
 
-	v.Name = "$" + v.Name
-
 	return nil
 }
+
+// Value converts v into types.Value as NamedValue does.
+func Value(v interface{}) (types.Value, error) {
+	return toValue(v)
+}
@@ -0,0 +1,256 @@
+package check
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"math/big"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/types"
+
+	"github.com/ydb-platform/ydb-go-sql/internal/decimal"
+)
+
+type custom int32
+
+type valuer struct{ v string }
+
+func (v *valuer) Value() (driver.Value, error) {
+	return v.v, nil
+}
+
+type series struct {
+	ID       uint64    `ydb:"series_id"`
+	Title    string    `ydb:"title"`
+	Released time.Time `ydb:"release_date,type=Date"`
+	Comment  string    `ydb:"comment,nullable"`
+	Rating   *float64  `ydb:"rating"`
+	Skipped  string    `ydb:"-"`
+	internal string
+}
+
+type badOption struct {
+	ID uint64 `ydb:"id,unknown"`
+}
+
+type badOverride struct {
+	ID uint64 `ydb:"id,type=Date"`
+}
+
+func seriesValue(id uint64, title string, released time.Time, comment types.Value) types.Value {
+	return types.StructValue(
+		types.StructFieldValue("series_id", types.Uint64Value(id)),
+		types.StructFieldValue("title", types.UTF8Value(title)),
+		types.StructFieldValue("release_date", types.DateValueFromTime(released)),
+		types.StructFieldValue("comment", comment),
+		types.StructFieldValue("rating", types.NullValue(types.TypeDouble)),
+	)
+}
+
+func TestNamedValue(t *testing.T) {
+	var (
+		now = time.Now()
+		i64 = int64(42)
+		s   = "foo"
+	)
+	for _, test := range []struct {
+		name string
+		v    interface{}
+		exp  types.Value
+		err  bool
+	}{
+		{name: "value", v: types.UTF8Value("foo"), exp: types.UTF8Value("foo")},
+		{name: "int", v: 42, exp: types.Int64Value(42)},
+		{name: "uint", v: uint(42), exp: types.Uint64Value(42)},
+		{name: "custom", v: custom(42), exp: types.Int32Value(42)},
+		{name: "bytes", v: []byte("foo"), exp: types.StringValue([]byte("foo"))},
+		{name: "uuid", v: [16]byte{1}, exp: types.UUIDValue([16]byte{1})},
+		{name: "time", v: now, exp: types.TimestampValueFromTime(now)},
+		{name: "duration", v: time.Second, exp: types.IntervalValueFromDuration(time.Second)},
+		{name: "pointer", v: &i64, exp: types.OptionalValue(types.Int64Value(42))},
+		{name: "nil pointer", v: (*string)(nil), exp: types.NullValue(types.TypeUTF8)},
+		{
+			name: "nil pointer to pointer",
+			v:    (**string)(nil),
+			exp:  types.NullValue(types.TypeUTF8),
+		},
+		{
+			name: "pointer to nil pointer",
+			v:    new(*string),
+			exp:  types.NullValue(types.TypeUTF8),
+		},
+		{
+			name: "pointer to pointer",
+			v:    func() **string { p := &s; return &p }(),
+			exp:  types.OptionalValue(types.UTF8Value("foo")),
+		},
+		{
+			name: "pointer to null string",
+			v:    &sql.NullString{String: "foo", Valid: true},
+			exp:  types.OptionalValue(types.UTF8Value("foo")),
+		},
+		{
+			name: "null string",
+			v:    sql.NullString{String: "foo", Valid: true},
+			exp:  types.OptionalValue(types.UTF8Value("foo")),
+		},
+		{name: "null int64", v: sql.NullInt64{}, exp: types.NullValue(types.TypeInt64)},
+		{name: "null time", v: sql.NullTime{}, exp: types.NullValue(types.TypeTimestamp)},
+		{name: "nil pointer to null bool", v: (*sql.NullBool)(nil), exp: types.NullValue(types.TypeBool)},
+		{name: "pointer valuer", v: &valuer{v: "foo"}, exp: types.UTF8Value("foo")},
+		{
+			name: "slice",
+			v:    []uint64{1, 2},
+			exp:  types.ListValue(types.Uint64Value(1), types.Uint64Value(2)),
+		},
+		{
+			name: "array",
+			v:    [2]string{"foo", "bar"},
+			exp:  types.ListValue(types.UTF8Value("foo"), types.UTF8Value("bar")),
+		},
+		{
+			name: "slice of pointers",
+			v:    []*int64{&i64, nil},
+			exp: types.ListValue(
+				types.OptionalValue(types.Int64Value(42)),
+				types.NullValue(types.TypeInt64),
+			),
+		},
+		{
+			name: "slice of interfaces",
+			v:    []interface{}{1, int64(2)},
+			exp:  types.ListValue(types.Int64Value(1), types.Int64Value(2)),
+		},
+		{name: "empty slice", v: []uint64{}, exp: types.ZeroValue(types.List(types.TypeUint64))},
+		{name: "nil slice", v: []string(nil), exp: types.ZeroValue(types.List(types.TypeUTF8))},
+		{
+			name: "map",
+			v:    map[string]int32{"foo": 1},
+			exp:  types.DictValue(types.UTF8Value("foo"), types.Int32Value(1)),
+		},
+		{name: "empty map", v: map[string]int32{}, err: true},
+		{
+			name: "slice of maps",
+			v:    []map[string]int32{{"foo": 1}},
+			exp:  types.ListValue(types.DictValue(types.UTF8Value("foo"), types.Int32Value(1))),
+		},
+		{name: "empty slice of maps", v: []map[string]int32{}, err: true},
+		{name: "nil pointer to map", v: (*map[string]int32)(nil), err: true},
+		{
+			name: "slice of structs with nullable map field",
+			v: []struct {
+				A map[string]int32 `ydb:"a,nullable"`
+			}{{}},
+			err: true,
+		},
+		{name: "empty slice of interfaces", v: []interface{}{}, err: true},
+		{name: "slice of mixed interfaces", v: []interface{}{1, "foo"}, err: true},
+		{name: "slice of unsupported", v: []chan int{make(chan int)}, err: true},
+		{name: "map of unsupported", v: map[string]chan int{"foo": nil}, err: true},
+		{
+			name: "struct",
+			v:    series{ID: 1, Title: "IT Crowd", Released: now, Comment: "foo", internal: "bar"},
+			exp:  seriesValue(1, "IT Crowd", now, types.OptionalValue(types.UTF8Value("foo"))),
+		},
+		{
+			name: "slice of structs",
+			v: []series{
+				{ID: 1, Title: "IT Crowd", Released: now},
+				{ID: 2, Title: "Silicon Valley", Released: now},
+			},
+			exp: types.ListValue(
+				seriesValue(1, "IT Crowd", now, types.NullValue(types.TypeUTF8)),
+				seriesValue(2, "Silicon Valley", now, types.NullValue(types.TypeUTF8)),
+			),
+		},
+		{
+			name: "empty slice of structs",
+			v:    []series{},
+			exp: types.ZeroValue(types.List(types.Struct(
+				types.StructField("series_id", types.TypeUint64),
+				types.StructField("title", types.TypeUTF8),
+				types.StructField("release_date", types.TypeDate),
+				types.StructField("comment", types.Optional(types.TypeUTF8)),
+				types.StructField("rating", types.Optional(types.TypeDouble)),
+			))),
+		},
+		{
+			name: "struct with null types",
+			v: struct {
+				A sql.NullString
+				B *sql.NullInt64
+			}{},
+			exp: types.StructValue(
+				types.StructFieldValue("A", types.NullValue(types.TypeUTF8)),
+				types.StructFieldValue("B", types.NullValue(types.TypeInt64)),
+			),
+		},
+		{
+			name: "slice of structs with nullable optional fields",
+			v: []struct {
+				A *sql.NullString `ydb:"a,nullable"`
+				B **int64         `ydb:"b,optional"`
+				C sql.NullInt32   `ydb:"c,nullable"`
+			}{{}},
+			exp: types.ListValue(types.StructValue(
+				types.StructFieldValue("a", types.NullValue(types.TypeUTF8)),
+				types.StructFieldValue("b", types.NullValue(types.TypeInt64)),
+				types.StructFieldValue("c", types.NullValue(types.TypeInt32)),
+			)),
+		},
+		{name: "struct with unknown option", v: badOption{}, err: true},
+		{name: "struct with inapplicable override", v: badOverride{}, err: true},
+		{
+			name: "decimal",
+			v:    decimal.Decimal{Unscaled: big.NewInt(12345), Precision: 22, Scale: 9},
+			exp:  types.DecimalValueFromBigInt(big.NewInt(12345), 22, 9),
+		},
+		{
+			name: "pointer to decimal",
+			v:    &decimal.Decimal{Unscaled: big.NewInt(12345), Precision: 22, Scale: 9},
+			exp:  types.OptionalValue(types.DecimalValueFromBigInt(big.NewInt(12345), 22, 9)),
+		},
+		{name: "nil pointer to decimal", v: (*decimal.Decimal)(nil), err: true},
+		{name: "json", v: json.RawMessage(`{"a":1}`), exp: types.JSONValue(`{"a":1}`)},
+		{name: "nil pointer to json", v: (*json.RawMessage)(nil), exp: types.NullValue(types.TypeJSON)},
+		{name: "untyped nil", v: nil, err: true},
+		{name: "unsupported", v: make(chan int), err: true},
+		{name: "nil pointer to unsupported", v: (*chan int)(nil), err: true},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			v := driver.NamedValue{Name: "p", Value: test.v}
+			err := NamedValue(&v)
+			if test.err {
+				if err == nil {
+					t.Fatalf("expected error, got %v", v.Value)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if v.Name != "$p" {
+				t.Fatalf("unexpected name: %q; want %q", v.Name, "$p")
+			}
+			if !reflect.DeepEqual(v.Value, test.exp) {
+				t.Fatalf("unexpected value: %v; want %v", v.Value, test.exp)
+			}
+		})
+	}
+}
+
+func TestNamedValuePositional(t *testing.T) {
+	v := driver.NamedValue{Ordinal: 1, Value: uint64(42)}
+	if err := NamedValue(&v); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if v.Name != "" {
+		t.Fatalf("unexpected name: %q", v.Name)
+	}
+	if exp := types.Uint64Value(42); !reflect.DeepEqual(v.Value, exp) {
+		t.Fatalf("unexpected value: %v; want %v", v.Value, exp)
+	}
+}
@@ -0,0 +1,263 @@
+package check
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/types"
+)
+
+// structField describes conversion of struct field to YDB struct member.
+//
+// Fields are configured with tag `ydb:"name[,option...]"` where options are:
+//
+//	optional  – field value is wrapped into Optional
+//	nullable  – same as optional, but zero value of field becomes null
+//	type=T    – field value is converted to YDB type T instead of default one
+//
+// Fields with tag `ydb:"-"` and unexported fields are skipped. Name of field
+// without tag is used as member name as is. Pointer fields are converted to
+// Optional members. Options optional and nullable do not add extra Optional
+// level to fields of pointer and sql.NullXxx types.
+type structField struct {
+	index    []int
+	name     string
+	optional bool
+	nullable bool
+	override types.Type
+
+	// t is a type of converted field value before wrapping into Optional
+	// with optional or nullable options. It is nil if type could not be
+	// inferred from Go type of field.
+	t types.Type
+}
+
+type structFields struct {
+	fields  []structField
+	members map[string]Member
+	err     error
+}
+
+// Member describes struct field which YDB struct member is decoded into.
+type Member struct {
+	// Index is an index sequence of field for reflect.Value.FieldByIndex.
+	Index []int
+
+	// Nullable reports whether field is tagged with optional or nullable
+	// option, so null member is decoded into zero value of field.
+	Nullable bool
+}
+
+var structCache sync.Map // reflect.Type -> *structFields
+
+var overrides = map[string]types.Type{
+	"Date":         types.TypeDate,
+	"Datetime":     types.TypeDatetime,
+	"Timestamp":    types.TypeTimestamp,
+	"Interval":     types.TypeInterval,
+	"String":       types.TypeString,
+	"Utf8":         types.TypeUTF8,
+	"Json":         types.TypeJSON,
+	"JsonDocument": types.TypeJSONDocument,
+	"Yson":         types.TypeYSON,
+}
+
+func fieldsOf(t reflect.Type) ([]structField, error) {
+	fs := cachedFields(t)
+	return fs.fields, fs.err
+}
+
+// Members returns fields of struct type t by names of YDB struct members.
+// Fields are configured with the same tags as for conversion of structs to
+// Struct values.
+func Members(t reflect.Type) (map[string]Member, error) {
+	fs := cachedFields(t)
+	return fs.members, fs.err
+}
+
+func cachedFields(t reflect.Type) *structFields {
+	if fs, ok := structCache.Load(t); ok {
+		return fs.(*structFields)
+	}
+	fs := new(structFields)
+	fs.fields, fs.err = parseFields(t)
+	if fs.err == nil {
+		fs.members = make(map[string]Member, len(fs.fields))
+		for _, f := range fs.fields {
+			fs.members[f.name] = Member{
+				Index:    f.index,
+				Nullable: f.optional || f.nullable,
+			}
+		}
+	}
+	structCache.Store(t, fs)
+	return fs
+}
+
+func parseFields(t reflect.Type) (fields []structField, err error) {
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if sf.PkgPath != "" {
+			continue
+		}
+		tag, ok := sf.Tag.Lookup("ydb")
+		if tag == "-" {
+			continue
+		}
+		f := structField{
+			index: sf.Index,
+			name:  sf.Name,
+		}
+		if ok {
+			opts := strings.Split(tag, ",")
+			if opts[0] != "" {
+				f.name = opts[0]
+			}
+			for _, opt := range opts[1:] {
+				switch {
+				case opt == "optional":
+					f.optional = true
+				case opt == "nullable":
+					f.nullable = true
+				case strings.HasPrefix(opt, "type="):
+					f.override, ok = overrides[strings.TrimPrefix(opt, "type=")]
+					if !ok {
+						return nil, fmt.Errorf("ydb: unknown type override %q of field %s.%s", opt, t, sf.Name)
+					}
+				default:
+					return nil, fmt.Errorf("ydb: unknown option %q of field %s.%s", opt, t, sf.Name)
+				}
+			}
+		}
+		if optional(sf.Type) {
+			// Values of field are Optional already.
+			f.optional, f.nullable = false, false
+		}
+		switch {
+		case f.override != nil && sf.Type.Kind() == reflect.Ptr:
+			f.t = types.Optional(f.override)
+		case f.override != nil:
+			f.t = f.override
+		default:
+			f.t, _ = toType(sf.Type)
+		}
+		fields = append(fields, f)
+	}
+	return fields, nil
+}
+
+func (f *structField) value(rv reflect.Value) (v types.Value, err error) {
+	fv := rv.FieldByIndex(f.index)
+	if f.nullable && fv.IsZero() {
+		if f.t == nil {
+			return nil, fmt.Errorf("ydb: unsupported type: %s (struct field %s)", fv.Type(), f.name)
+		}
+		return types.NullValue(f.t), nil
+	}
+	switch {
+	case f.override != nil && fv.Kind() == reflect.Ptr:
+		if fv.IsNil() {
+			return types.NullValue(f.override), nil
+		}
+		v, err = override(f.override, fv.Elem())
+		if err == nil {
+			v = types.OptionalValue(v)
+		}
+	case f.override != nil:
+		v, err = override(f.override, fv)
+	default:
+		v, err = toValue(fv.Interface())
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w (struct field %s)", err, f.name)
+	}
+	if f.optional || f.nullable {
+		return types.OptionalValue(v), nil
+	}
+	return v, nil
+}
+
+func (f *structField) typ(t reflect.Type) (types.Type, error) {
+	if f.t == nil {
+		return nil, fmt.Errorf("ydb: unsupported type: %s (struct field %s)", t, f.name)
+	}
+	if f.optional || f.nullable {
+		return types.Optional(f.t), nil
+	}
+	return f.t, nil
+}
+
+// override converts v to value of YDB type t.
+func override(t types.Type, v reflect.Value) (types.Value, error) {
+	switch {
+	case v.Type() == typeTime:
+		tm := v.Interface().(time.Time)
+		switch t {
+		case types.TypeDate:
+			return types.DateValueFromTime(tm), nil
+		case types.TypeDatetime:
+			return types.DatetimeValueFromTime(tm), nil
+		case types.TypeTimestamp:
+			return types.TimestampValueFromTime(tm), nil
+		}
+	case v.Kind() == reflect.Int64 && t == types.TypeInterval:
+		return types.IntervalValue(v.Int()), nil
+	case v.Kind() == reflect.String, v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.Uint8:
+		var s string
+		if v.Kind() == reflect.String {
+			s = v.String()
+		} else {
+			s = string(v.Bytes())
+		}
+		switch t {
+		case types.TypeString:
+			return types.StringValueFromString(s), nil
+		case types.TypeUTF8:
+			return types.UTF8Value(s), nil
+		case types.TypeJSON:
+			return types.JSONValue(s), nil
+		case types.TypeJSONDocument:
+			return types.JSONDocumentValue(s), nil
+		case types.TypeYSON:
+			return types.YSONValue(s), nil
+		}
+	}
+	return nil, fmt.Errorf("ydb: type override %s is not applicable to %s", t, v.Type())
+}
+
+// structValue converts struct to Struct value.
+func structValue(rv reflect.Value) (types.Value, error) {
+	fields, err := fieldsOf(rv.Type())
+	if err != nil {
+		return nil, err
+	}
+	opts := make([]types.StructValueOption, len(fields))
+	for i := range fields {
+		v, err := fields[i].value(rv)
+		if err != nil {
+			return nil, err
+		}
+		opts[i] = types.StructFieldValue(fields[i].name, v)
+	}
+	return types.StructValue(opts...), nil
+}
+
+// structType returns Struct type of values converted from structs of type t.
+func structType(t reflect.Type) (types.Type, error) {
+	fields, err := fieldsOf(t)
+	if err != nil {
+		return nil, err
+	}
+	opts := make([]types.StructOption, len(fields))
+	for i := range fields {
+		ft, err := fields[i].typ(t.FieldByIndex(fields[i].index).Type)
+		if err != nil {
+			return nil, err
+		}
+		opts[i] = types.StructField(fields[i].name, ft)
+	}
+	return types.Struct(opts...), nil
+}
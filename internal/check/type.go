@@ -0,0 +1,109 @@
+package check
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/types"
+)
+
+var (
+	typeTime     = reflect.TypeOf(time.Time{})
+	typeDuration = reflect.TypeOf(time.Duration(0))
+	typeJSON     = reflect.TypeOf(json.RawMessage(nil))
+)
+
+// nullTypes maps sql.NullXxx types to types of their values.
+var nullTypes = map[reflect.Type]types.Type{
+	reflect.TypeOf(sql.NullBool{}):    types.TypeBool,
+	reflect.TypeOf(sql.NullInt32{}):   types.TypeInt32,
+	reflect.TypeOf(sql.NullInt64{}):   types.TypeInt64,
+	reflect.TypeOf(sql.NullFloat64{}): types.TypeDouble,
+	reflect.TypeOf(sql.NullString{}):  types.TypeUTF8,
+	reflect.TypeOf(sql.NullTime{}):    types.TypeTimestamp,
+}
+
+// toType returns YDB type which values of Go type t are converted to by
+// toValue. Pointers to sql.NullXxx types and pointers to pointers are mapped
+// to the same type as their elements, without extra Optional level.
+func toType(t reflect.Type) (types.Type, error) {
+	switch t {
+	case typeTime:
+		return types.TypeTimestamp, nil
+	case typeDuration:
+		return types.TypeInterval, nil
+	case typeJSON:
+		return types.TypeJSON, nil
+	}
+	if nt, ok := nullTypes[t]; ok {
+		return types.Optional(nt), nil
+	}
+	if t.Implements(typeValuer) {
+		return nil, fmt.Errorf("ydb: unsupported type: %s: type of driver.Valuer could not be inferred", t)
+	}
+
+	switch t.Kind() {
+	case reflect.Ptr:
+		elem, err := toType(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		if optional(t.Elem()) {
+			return elem, nil
+		}
+		return types.Optional(elem), nil
+	case reflect.Bool:
+		return types.TypeBool, nil
+	case reflect.Int8:
+		return types.TypeInt8, nil
+	case reflect.Uint8:
+		return types.TypeUint8, nil
+	case reflect.Int16:
+		return types.TypeInt16, nil
+	case reflect.Uint16:
+		return types.TypeUint16, nil
+	case reflect.Int32:
+		return types.TypeInt32, nil
+	case reflect.Uint32:
+		return types.TypeUint32, nil
+	case reflect.Int, reflect.Int64:
+		return types.TypeInt64, nil
+	case reflect.Uint, reflect.Uint64:
+		return types.TypeUint64, nil
+	case reflect.Float32:
+		return types.TypeFloat, nil
+	case reflect.Float64:
+		return types.TypeDouble, nil
+	case reflect.String:
+		return types.TypeUTF8, nil
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return types.TypeString, nil
+		}
+		return listType(t)
+	case reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 && t.Len() == 16 {
+			return types.TypeUUID, nil
+		}
+		return listType(t)
+	case reflect.Struct:
+		return structType(t)
+	case reflect.Map:
+		// Dict type could not be constructed with ydb-go-sdk v3.5.2, so it is
+		// inferred by dictValue from items of non-empty map only.
+		return nil, fmt.Errorf("ydb: unsupported type: %s: type of dict could not be inferred without items", t)
+	}
+
+	return nil, fmt.Errorf("ydb: unsupported type: %s", t)
+}
+
+func listType(t reflect.Type) (types.Type, error) {
+	item, err := toType(t.Elem())
+	if err != nil {
+		return nil, err
+	}
+	return types.List(item), nil
+}
@@ -0,0 +1,217 @@
+package check
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/types"
+)
+
+// toValue converts Go value to YDB value.
+//
+// Pointers are converted to Optional values, nil pointers to typed nulls.
+// sql.NullXxx values are converted to Optional values too. Pointers to them
+// and pointers to pointers are converted to values of single Optional level. Structs are
+// converted to Struct values, see structField for details.
+func toValue(v interface{}) (_ types.Value, err error) {
+	switch x := v.(type) {
+	case nil:
+		return nil, fmt.Errorf("ydb: untyped nil is not supported, use ydb.Null() instead")
+	case types.Value:
+		return x, nil
+	case time.Time:
+		return types.TimestampValueFromTime(x), nil
+	case time.Duration:
+		return types.IntervalValueFromDuration(x), nil
+	case json.RawMessage:
+		return types.JSONValueFromBytes(x), nil
+	case sql.NullBool:
+		return nullable(x.Valid, types.TypeBool, func() types.Value {
+			return types.BoolValue(x.Bool)
+		}), nil
+	case sql.NullInt32:
+		return nullable(x.Valid, types.TypeInt32, func() types.Value {
+			return types.Int32Value(x.Int32)
+		}), nil
+	case sql.NullInt64:
+		return nullable(x.Valid, types.TypeInt64, func() types.Value {
+			return types.Int64Value(x.Int64)
+		}), nil
+	case sql.NullFloat64:
+		return nullable(x.Valid, types.TypeDouble, func() types.Value {
+			return types.DoubleValue(x.Float64)
+		}), nil
+	case sql.NullString:
+		return nullable(x.Valid, types.TypeUTF8, func() types.Value {
+			return types.UTF8Value(x.String)
+		}), nil
+	case sql.NullTime:
+		return nullable(x.Valid, types.TypeTimestamp, func() types.Value {
+			return types.TimestampValueFromTime(x.Time)
+		}), nil
+	}
+
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Ptr && !pointerValuer(rv.Type()) {
+		if elem := rv.Type().Elem(); optional(elem) {
+			// Pointer to sql.NullXxx or to another pointer does not add
+			// extra Optional level.
+			if rv.IsNil() {
+				return toValue(reflect.Zero(elem).Interface())
+			}
+			return toValue(rv.Elem().Interface())
+		}
+		if rv.IsNil() {
+			t, err := toType(rv.Type().Elem())
+			if err != nil {
+				return nil, err
+			}
+			return types.NullValue(t), nil
+		}
+		value, err := toValue(rv.Elem().Interface())
+		if err != nil {
+			return nil, err
+		}
+		return types.OptionalValue(value), nil
+	}
+
+	if valuer, ok := v.(driver.Valuer); ok {
+		v, err = valuer.Value()
+		if err != nil {
+			return nil, fmt.Errorf("ydb: driver.Valuer error: %w", err)
+		}
+		return toValue(v)
+	}
+
+	switch rv.Kind() {
+	case reflect.Bool:
+		return types.BoolValue(rv.Bool()), nil
+	case reflect.Int8:
+		return types.Int8Value(int8(rv.Int())), nil
+	case reflect.Uint8:
+		return types.Uint8Value(uint8(rv.Uint())), nil
+	case reflect.Int16:
+		return types.Int16Value(int16(rv.Int())), nil
+	case reflect.Uint16:
+		return types.Uint16Value(uint16(rv.Uint())), nil
+	case reflect.Int32:
+		return types.Int32Value(int32(rv.Int())), nil
+	case reflect.Uint32:
+		return types.Uint32Value(uint32(rv.Uint())), nil
+	case reflect.Int, reflect.Int64:
+		return types.Int64Value(rv.Int()), nil
+	case reflect.Uint, reflect.Uint64:
+		return types.Uint64Value(rv.Uint()), nil
+	case reflect.Float32:
+		return types.FloatValue(float32(rv.Float())), nil
+	case reflect.Float64:
+		return types.DoubleValue(rv.Float()), nil
+	case reflect.String:
+		return types.UTF8Value(rv.String()), nil
+	case reflect.Slice:
+		if rv.Type().Elem().Kind() == reflect.Uint8 {
+			return types.StringValue(rv.Bytes()), nil
+		}
+		return listValue(rv)
+	case reflect.Array:
+		if rv.Type().Elem().Kind() == reflect.Uint8 && rv.Len() == 16 {
+			var uuid [16]byte
+			reflect.Copy(reflect.ValueOf(&uuid).Elem(), rv)
+			return types.UUIDValue(uuid), nil
+		}
+		return listValue(rv)
+	case reflect.Map:
+		return dictValue(rv)
+	case reflect.Struct:
+		return structValue(rv)
+	}
+
+	return nil, fmt.Errorf("ydb: unsupported type: %T", v)
+}
+
+// listValue converts slice or array to List value. Type of empty list is
+// inferred from Go type of its items.
+func listValue(rv reflect.Value) (types.Value, error) {
+	if rv.Len() == 0 {
+		t, err := toType(rv.Type().Elem())
+		if err != nil {
+			return nil, fmt.Errorf("ydb: unsupported type: %s: cannot infer type of empty list, use ydb.EmptyList() instead", rv.Type())
+		}
+		return types.ZeroValue(types.List(t)), nil
+	}
+	items := make([]types.Value, rv.Len())
+	for i := range items {
+		item, err := toValue(rv.Index(i).Interface())
+		if err != nil {
+			return nil, fmt.Errorf("%w (list item %d)", err, i)
+		}
+		items[i] = item
+	}
+	return container(func() types.Value {
+		return types.ListValue(items...)
+	})
+}
+
+// dictValue converts map to Dict value. Empty maps are not supported because
+// Dict type could not be constructed without its values.
+func dictValue(rv reflect.Value) (types.Value, error) {
+	if rv.Len() == 0 {
+		return nil, fmt.Errorf("ydb: unsupported type: %s: type of dict could not be inferred without items", rv.Type())
+	}
+	pairs := make([]types.Value, 0, 2*rv.Len())
+	it := rv.MapRange()
+	for it.Next() {
+		k, err := toValue(it.Key().Interface())
+		if err != nil {
+			return nil, fmt.Errorf("%w (dict key %v)", err, it.Key())
+		}
+		v, err := toValue(it.Value().Interface())
+		if err != nil {
+			return nil, fmt.Errorf("%w (dict value of key %v)", err, it.Key())
+		}
+		pairs = append(pairs, k, v)
+	}
+	return container(func() types.Value {
+		return types.DictValue(pairs...)
+	})
+}
+
+// container calls f which builds container value and converts its panic on
+// mismatched types of items into an error.
+func container(f func() types.Value) (v types.Value, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = fmt.Errorf("ydb: %v", e)
+		}
+	}()
+	return f(), nil
+}
+
+var typeValuer = reflect.TypeOf((*driver.Valuer)(nil)).Elem()
+
+// pointerValuer reports whether t is a pointer type which implements
+// driver.Valuer with pointer receiver. Such pointers are passed to Value()
+// as is instead of conversion to Optional.
+func pointerValuer(t reflect.Type) bool {
+	return t.Implements(typeValuer) && !t.Elem().Implements(typeValuer)
+}
+
+// optional reports whether values of Go type t are converted to Optional
+// values, i.e. t is a pointer or sql.NullXxx type.
+func optional(t reflect.Type) bool {
+	if _, ok := nullTypes[t]; ok {
+		return true
+	}
+	return t.Kind() == reflect.Ptr && !pointerValuer(t)
+}
+
+func nullable(valid bool, t types.Type, value func() types.Value) types.Value {
+	if !valid {
+		return types.NullValue(t)
+	}
+	return types.OptionalValue(value())
+}
@@ -13,12 +13,15 @@ import (
 	"github.com/ydb-platform/ydb-go-sql/internal/errors"
 	"github.com/ydb-platform/ydb-go-sql/internal/mode"
 	"github.com/ydb-platform/ydb-go-sql/internal/nop"
+	"github.com/ydb-platform/ydb-go-sql/internal/query"
+	"github.com/ydb-platform/ydb-go-sql/internal/querylog"
 	"github.com/ydb-platform/ydb-go-sql/internal/rows"
 	"github.com/ydb-platform/ydb-go-sql/internal/single"
 	"github.com/ydb-platform/ydb-go-sql/internal/stmt"
 	"github.com/ydb-platform/ydb-go-sql/internal/stream"
 	"github.com/ydb-platform/ydb-go-sql/internal/tx"
 	"github.com/ydb-platform/ydb-go-sql/internal/x"
+	"github.com/ydb-platform/ydb-go-sql/trace"
 )
 
 type Conn interface {
@@ -27,6 +30,11 @@ type Conn interface {
 	driver.ExecerContext
 
 	driver.NamedValueChecker
+	driver.SessionResetter
+	driver.Validator
+
+	CommitContext(ctx context.Context) error
+	RollbackContext(ctx context.Context) error
 }
 
 // conn is a connection to the ydb.
@@ -37,19 +45,32 @@ type conn struct {
 	defaultTxControl *table.TransactionControl
 	dataOpts         []options.ExecuteDataQueryOption
 	scanOpts         []options.ExecuteScanQueryOption
+	query            query.Querier
+	trace            trace.Trace
+	log              *querylog.Log
 
 	idle bool
+
+	// bad is set when session invalidated by server.
+	bad bool
 }
 
 func New(s table.ClosableSession, opts ...Option) Conn {
-	c := &conn{s: s}
+	c := &conn{s: s, query: query.New()}
 	for _, o := range opts {
 		o(c)
 	}
 	return c
 }
 
-func (c *conn) ResetSession(ctx context.Context) error {
+func (c *conn) ResetSession(ctx context.Context) (err error) {
+	onDone := trace.TraceOnResetSession(ctx, c.trace, &ctx)
+	defer func() {
+		onDone(err)
+	}()
+	if c.bad {
+		return driver.ErrBadConn
+	}
 	if c.idle {
 		return nil
 	}
@@ -57,32 +78,117 @@ func (c *conn) ResetSession(ctx context.Context) error {
 	return nil
 }
 
-func (c *conn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+func (c *conn) PrepareContext(ctx context.Context, query string) (_ driver.Stmt, err error) {
+	q, err := c.query.Prepare(ctx, query)
+	if err == nil {
+		query = q
+	}
+	onDone := trace.TraceOnPrepare(ctx, c.trace, &ctx, query, c.txID())
+	defer func() {
+		onDone(err)
+	}()
+	if err != nil {
+		return nil, err
+	}
 	s, err := c.s.Prepare(ctx, query)
 	if err != nil {
 		return nil, errors.Map(err)
 	}
-	return stmt.New(s, c.defaultTxControl), nil
+	return stmt.New(s, c.defaultTxControl, c.dataOpts, c.query, c.trace, c.log, c.explain, c.activeTx), nil
 }
 
 func (c *conn) BeginTx(ctx context.Context, opts driver.TxOptions) (_ driver.Tx, err error) {
+	// Context of hook is used for Begin call only. Transaction keeps ctx, so
+	// Commit and Rollback are not traced within finished Begin.
+	begin := ctx
+	onDone := trace.TraceOnBegin(ctx, c.trace, &begin, opts.Isolation, opts.ReadOnly)
+	defer func() {
+		onDone(c.txID(), err)
+	}()
+	if c.bad {
+		return nil, driver.ErrBadConn
+	}
 	if c.tx != nil {
 		return nil, errors.ErrActiveTransaction
 	}
-	c.tx, err = tx.New(ctx, opts, c.s, func() { c.tx = nil })
-	return c.tx, err
+	t, err := tx.New(ctx, begin, opts, c.s, c.dataOpts, c.detach)
+	if err != nil {
+		return nil, err
+	}
+	c.tx = tx.WithTrace(ctx, tx.WithLog(ctx, t, c.log), c.trace)
+	return c.tx, nil
+}
+
+// activeTx returns active transaction of connection or nil.
+func (c *conn) activeTx() tx.Tx {
+	return c.tx
+}
+
+// txID returns identifier of active transaction or empty string.
+func (c *conn) txID() string {
+	if c.tx == nil {
+		return ""
+	}
+	return c.tx.ID()
+}
+
+// detach detaches finished transaction from connection and marks connection
+// as bad if transaction finished with error which invalidates session.
+func (c *conn) detach(err error) {
+	c.tx = nil
+	if errors.Map(err) == driver.ErrBadConn {
+		c.bad = true
+	}
+}
+
+// CommitContext commits active transaction of connection with ctx.
+func (c *conn) CommitContext(ctx context.Context) error {
+	if c.tx == nil {
+		return errors.ErrNoActiveTransaction
+	}
+	return c.tx.CommitContext(ctx)
+}
+
+// RollbackContext rollbacks active transaction of connection with ctx.
+func (c *conn) RollbackContext(ctx context.Context) error {
+	if c.tx == nil {
+		return errors.ErrNoActiveTransaction
+	}
+	return c.tx.RollbackContext(ctx)
 }
 
-func (c *conn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+func (c *conn) IsValid() bool {
+	return !c.bad
+}
+
+func (c *conn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (_ driver.Result, err error) {
+	q, a, err := c.query.Query(ctx, query, args)
+	if err == nil {
+		query, args = q, a
+	}
+	onDone := trace.TraceOnExec(ctx, c.trace, &ctx, query, x.QueryMode(ctx).String(), len(args), c.txID(), false)
+	onLog := c.log.Start(ctx, c.entry(ctx, "ydb: exec", query, args))
+	defer func() {
+		onDone(err)
+		onLog(-1, err)
+	}()
+	if err != nil {
+		return nil, err
+	}
+	return c.exec(ctx, query, args)
+}
+
+func (c *conn) exec(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
 	if c.tx != nil {
 		return c.tx.ExecContext(ctx, query, args)
 	}
 	switch m := x.QueryMode(ctx); m {
 	case mode.DataQuery:
-		_, res, err := c.s.Execute(ctx, x.TxControl(ctx, c.defaultTxControl), query, x.ToQueryParams(args))
+		_, res, err := c.s.Execute(ctx, x.TxControl(ctx, c.defaultTxControl), query, x.ToQueryParams(args), x.DataQueryOptions(ctx, c.dataOpts)...)
 		if err != nil {
 			return nil, errors.Map(err)
 		}
+		x.ReportStats(ctx, res.Stats())
 		return nop.Result(), errors.Map(res.Err())
 	case mode.SchemeQuery:
 		err := c.s.ExecuteSchemeQuery(ctx, query, x.ToSchemeOptions(args)...)
@@ -95,19 +201,47 @@ func (c *conn) ExecContext(ctx context.Context, query string, args []driver.Name
 	}
 }
 
-func (c *conn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+func (c *conn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (_ driver.Rows, err error) {
+	q, a, err := c.query.Query(ctx, query, args)
+	if err == nil {
+		query, args = q, a
+	}
+	onDone := trace.TraceOnQuery(ctx, c.trace, &ctx, query, x.QueryMode(ctx).String(), len(args), c.txID(), false)
+	onLog := c.log.Start(ctx, c.entry(ctx, "ydb: query", query, args))
+	defer func() {
+		onDone(err)
+		if err != nil {
+			onLog(0, err)
+		}
+	}()
+	if err != nil {
+		return nil, err
+	}
+	r, err := c.queryContext(ctx, query, args)
+	if r == nil {
+		return nil, err
+	}
+	if c.log != nil {
+		// Query is logged with number of read rows on close of rows.
+		r = rows.WithCount(r, onLog)
+	}
+	return rows.WithTrace(ctx, r, c.trace), err
+}
+
+func (c *conn) queryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
 	if c.tx != nil {
 		return c.tx.QueryContext(ctx, query, args)
 	}
 	switch m := x.QueryMode(ctx); m {
 	case mode.DataQuery:
-		_, res, err := c.s.Execute(ctx, x.TxControl(ctx, c.defaultTxControl), query, x.ToQueryParams(args))
+		_, res, err := c.s.Execute(ctx, x.TxControl(ctx, c.defaultTxControl), query, x.ToQueryParams(args), x.DataQueryOptions(ctx, c.dataOpts)...)
 		if err != nil {
 			return nil, errors.Map(err)
 		}
+		x.ReportStats(ctx, res.Stats())
 		return rows.Result(res), errors.Map(res.Err())
 	case mode.ScanQuery:
-		res, err := c.s.StreamExecuteScanQuery(ctx, query, x.ToQueryParams(args), x.ScanQueryOptions(ctx)...)
+		res, err := c.s.StreamExecuteScanQuery(ctx, query, x.ToQueryParams(args), x.ScanQueryOptions(ctx, c.scanOpts)...)
 		if err != nil {
 			return nil, errors.Map(err)
 		}
@@ -126,18 +260,51 @@ func (c *conn) QueryContext(ctx context.Context, query string, args []driver.Nam
 	}
 }
 
+// entry returns log entry of query executed on connection. Data and scan
+// queries could be explained for records of slow queries.
+func (c *conn) entry(ctx context.Context, msg, query string, args []driver.NamedValue) querylog.Entry {
+	m := x.QueryMode(ctx)
+	e := querylog.Entry{
+		Msg:   msg,
+		Query: query,
+		Mode:  m.String(),
+		Args:  args,
+		TxID:  c.txID(),
+	}
+	if m == mode.DataQuery || m == mode.ScanQuery {
+		e.Explain = c.explain
+	}
+	return e
+}
+
+// explain returns plan of query.
+func (c *conn) explain(ctx context.Context, query string) (string, error) {
+	exp, err := c.s.Explain(ctx, query)
+	if err != nil {
+		return "", errors.Map(err)
+	}
+	return exp.Plan, nil
+}
+
 func (c *conn) CheckNamedValue(v *driver.NamedValue) error {
 	return check.NamedValue(v)
 }
 
-func (c *conn) Ping(ctx context.Context) error {
+func (c *conn) Ping(ctx context.Context) (err error) {
+	onDone := trace.TraceOnPing(ctx, c.trace, &ctx)
+	defer func() {
+		onDone(err)
+	}()
 	return errors.Map(c.s.KeepAlive(ctx))
 }
 
-func (c *conn) Close() error {
+func (c *conn) Close() (err error) {
 	ctx := context.Background()
-	err := c.s.Close(ctx)
-	return errors.Map(err)
+	onDone := trace.TraceOnClose(ctx, c.trace, &ctx)
+	defer func() {
+		onDone(err)
+	}()
+	return errors.Map(c.s.Close(ctx))
 }
 
 func (c *conn) Prepare(string) (driver.Stmt, error) {
@@ -0,0 +1,140 @@
+package conn
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"testing"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/table"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/options"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/result"
+
+	"github.com/ydb-platform/ydb-go-sql/trace"
+)
+
+var (
+	errAutoCommit = errors.New("executed with auto-commit")
+	errInTx       = errors.New("executed within tx")
+)
+
+type fakeSession struct {
+	table.ClosableSession
+}
+
+func (fakeSession) Prepare(_ context.Context, query string) (table.Statement, error) {
+	return fakeStmt(query), nil
+}
+
+func (fakeSession) BeginTransaction(context.Context, *table.TransactionSettings) (table.Transaction, error) {
+	return fakeTx{}, nil
+}
+
+func (fakeSession) Close(context.Context) error {
+	return nil
+}
+
+type fakeStmt string
+
+func (s fakeStmt) Text() string { return string(s) }
+func (fakeStmt) NumInput() int  { return -1 }
+func (fakeStmt) Execute(
+	context.Context, *table.TransactionControl, *table.QueryParameters, ...options.ExecuteDataQueryOption,
+) (table.Transaction, result.Result, error) {
+	return nil, nil, errAutoCommit
+}
+
+type fakeTx struct {
+	table.Transaction
+}
+
+func (fakeTx) ID() string { return "tx" }
+func (fakeTx) ExecuteStatement(
+	context.Context, table.Statement, *table.QueryParameters, ...options.ExecuteDataQueryOption,
+) (result.Result, error) {
+	return nil, errInTx
+}
+func (fakeTx) Rollback(context.Context) error { return nil }
+func (fakeTx) CommitTx(context.Context, ...options.CommitTransactionOption) (result.Result, error) {
+	return nil, nil
+}
+
+type sessionConnector struct{}
+
+func (sessionConnector) Connect(context.Context) (driver.Conn, error) { return New(fakeSession{}), nil }
+func (sessionConnector) Driver() driver.Driver                        { return nil }
+
+func TestStmtWithinTx(t *testing.T) {
+	db := sql.OpenDB(sessionConnector{})
+	defer func() {
+		_ = db.Close()
+	}()
+	// Single connection makes database/sql reuse statement prepared on db
+	// within tx instead of preparing it again.
+	db.SetMaxOpenConns(1)
+
+	ctx := context.Background()
+	s, err := db.PrepareContext(ctx, "UPSERT INTO t (a) VALUES (1)")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		_ = s.Close()
+	}()
+	if _, err = s.ExecContext(ctx); !errors.Is(err, errAutoCommit) {
+		t.Fatalf("unexpected error: %v; want %v", err, errAutoCommit)
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+	if _, err = tx.StmtContext(ctx, s).ExecContext(ctx); !errors.Is(err, errInTx) {
+		t.Fatalf("unexpected error: %v; want %v", err, errInTx)
+	}
+}
+
+type spanKey struct{}
+
+// spanSession records span of context of BeginTransaction call.
+type spanSession struct {
+	fakeSession
+
+	span *string
+}
+
+func (s spanSession) BeginTransaction(ctx context.Context, settings *table.TransactionSettings) (table.Transaction, error) {
+	*s.span, _ = ctx.Value(spanKey{}).(string)
+	return s.fakeSession.BeginTransaction(ctx, settings)
+}
+
+func TestBeginTraceContext(t *testing.T) {
+	var beginSpan, commitParent string
+	c := New(spanSession{span: &beginSpan}, WithTrace(trace.Trace{
+		OnBegin: func(info trace.BeginStartInfo) func(trace.BeginDoneInfo) {
+			*info.Context = context.WithValue(*info.Context, spanKey{}, "begin")
+			return nil
+		},
+		OnCommit: func(info trace.CommitStartInfo) func(trace.CommitDoneInfo) {
+			commitParent, _ = (*info.Context).Value(spanKey{}).(string)
+			return nil
+		},
+	}))
+	tx, err := c.(driver.ConnBeginTx).BeginTx(context.Background(), driver.TxOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = tx.Commit(); err != nil {
+		t.Fatal(err)
+	}
+	if beginSpan != "begin" {
+		t.Fatalf("transaction is not begun with context of hook")
+	}
+	if commitParent != "" {
+		t.Fatalf("commit is traced within context of begin hook")
+	}
+}
@@ -0,0 +1,53 @@
+package conn
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/ydb-platform/ydb-go-sql/internal/errors"
+	"github.com/ydb-platform/ydb-go-sql/internal/retry"
+)
+
+type ConnOperationFunc func(context.Context, *sql.Conn) error
+
+// ConnDoer contains options for retrying operations on a single connection
+// outside of explicit transaction.
+type ConnDoer struct {
+	DB *sql.DB
+
+	// RetryOptions configures retry policy. If empty, operation retried
+	// until context is done with default backoffs and idempotency flag
+	// from context.
+	RetryOptions []retry.Option
+}
+
+// Do takes a connection from DB pool and calls f with it. If f() call returns
+// a retryable error, it repeats it on another connection accordingly to retry
+// configuration that ConnDoer holds.
+//
+// Note that queries within f are executed with implicit transaction control,
+// so f should be idempotent or context should not be marked as idempotent.
+// Non-idempotent f is retried only if it failed before execution, i.e. on
+// getting connection from the pool.
+func (d ConnDoer) Do(ctx context.Context, f ConnOperationFunc) error {
+	return errors.Map(retry.Retry(ctx, func(ctx context.Context) (err error) {
+		return d.do(ctx, f)
+	}, d.RetryOptions...))
+}
+
+func (d ConnDoer) do(ctx context.Context, f ConnOperationFunc) error {
+	cc, err := d.DB.Conn(ctx)
+	if err != nil {
+		return retry.NotExecuted(err)
+	}
+	defer func() {
+		_ = cc.Close()
+	}()
+	return f(ctx, cc)
+}
+
+// Do is a shortcut for calling Do(x, f) on initialized ConnDoer with DB
+// field set to given db.
+func Do(ctx context.Context, db *sql.DB, f ConnOperationFunc, opts ...retry.Option) error {
+	return (ConnDoer{DB: db, RetryOptions: opts}).Do(ctx, f)
+}
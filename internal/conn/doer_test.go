@@ -0,0 +1,58 @@
+package conn
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"testing"
+
+	"github.com/ydb-platform/ydb-go-sql/internal/retry"
+)
+
+type stubConnector struct{}
+
+func (stubConnector) Connect(context.Context) (driver.Conn, error) { return stubConn{}, nil }
+func (stubConnector) Driver() driver.Driver                        { return nil }
+
+type stubConn struct{}
+
+func (stubConn) Prepare(string) (driver.Stmt, error) { return nil, driver.ErrSkip }
+func (stubConn) Close() error                        { return nil }
+func (stubConn) Begin() (driver.Tx, error)           { return nil, driver.ErrSkip }
+
+func TestDoNonIdempotent(t *testing.T) {
+	db := sql.OpenDB(stubConnector{})
+	defer func() {
+		_ = db.Close()
+	}()
+	for _, test := range []struct {
+		name       string
+		idempotent bool
+		attempts   int
+	}{
+		{name: "idempotent", idempotent: true, attempts: 2},
+		{name: "non-idempotent", idempotent: false, attempts: 1},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			var attempts int
+			err := Do(context.Background(), db, func(context.Context, *sql.Conn) error {
+				attempts++
+				if attempts == 1 {
+					// Transport errors are mapped to driver.ErrBadConn, so
+					// it is unknown whether queries have been applied.
+					return driver.ErrBadConn
+				}
+				return nil
+			}, retry.WithIdempotent(test.idempotent))
+			if test.idempotent && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !test.idempotent && err != driver.ErrBadConn {
+				t.Fatalf("unexpected error: %v; want %v", err, driver.ErrBadConn)
+			}
+			if attempts != test.attempts {
+				t.Fatalf("unexpected attempts: %d; want %d", attempts, test.attempts)
+			}
+		})
+	}
+}
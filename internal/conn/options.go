@@ -3,6 +3,10 @@ package conn
 import (
 	"github.com/ydb-platform/ydb-go-sdk/v3/table"
 	"github.com/ydb-platform/ydb-go-sdk/v3/table/options"
+
+	"github.com/ydb-platform/ydb-go-sql/internal/query"
+	"github.com/ydb-platform/ydb-go-sql/internal/querylog"
+	"github.com/ydb-platform/ydb-go-sql/trace"
 )
 
 type Option func(*conn)
@@ -24,3 +28,21 @@ func WithDefaultTxControl(defaultTxControl *table.TransactionControl) Option {
 		c.defaultTxControl = defaultTxControl
 	}
 }
+
+func WithQuerier(q query.Querier) Option {
+	return func(c *conn) {
+		c.query = q
+	}
+}
+
+func WithTrace(t trace.Trace) Option {
+	return func(c *conn) {
+		c.trace = t
+	}
+}
+
+func WithLog(l *querylog.Log) Option {
+	return func(c *conn) {
+		c.log = l
+	}
+}
@@ -11,6 +11,9 @@ import (
 	"github.com/ydb-platform/ydb-go-sdk/v3/table/options"
 
 	"github.com/ydb-platform/ydb-go-sql/internal/conn"
+	"github.com/ydb-platform/ydb-go-sql/internal/query"
+	"github.com/ydb-platform/ydb-go-sql/internal/querylog"
+	"github.com/ydb-platform/ydb-go-sql/trace"
 )
 
 type Connector interface {
@@ -60,6 +63,11 @@ type connector struct {
 
 	dataOpts []options.ExecuteDataQueryOption
 	scanOpts []options.ExecuteScanQueryOption
+
+	queryOpts []query.Option
+
+	trace trace.Trace
+	log   *querylog.Log
 }
 
 func (c *connector) Close(ctx context.Context) error {
@@ -91,6 +99,10 @@ func (c *connector) init(ctx context.Context) (err error) {
 }
 
 func (c *connector) Connect(ctx context.Context) (_ driver.Conn, err error) {
+	onDone := trace.TraceOnConnect(ctx, c.trace, &ctx)
+	defer func() {
+		onDone(err)
+	}()
 	if err = c.init(ctx); err != nil {
 		return nil, err
 	}
@@ -112,6 +124,9 @@ func (c *connector) Connect(ctx context.Context) (_ driver.Conn, err error) {
 			conn.WithDefaultTxControl(c.defaultTxControl),
 			conn.WithDataOpts(c.dataOpts),
 			conn.WithScanOpts(c.scanOpts),
+			conn.WithQuerier(query.New(c.queryOpts...)),
+			conn.WithTrace(c.trace),
+			conn.WithLog(c.log),
 		), nil
 	}
 	return nil, err
@@ -12,6 +12,10 @@ import (
 	"github.com/ydb-platform/ydb-go-sdk/v3/credentials"
 	"github.com/ydb-platform/ydb-go-sdk/v3/table/options"
 	"github.com/ydb-platform/ydb-go-sdk/v3/trace"
+
+	"github.com/ydb-platform/ydb-go-sql/internal/query"
+	"github.com/ydb-platform/ydb-go-sql/internal/querylog"
+	sqltrace "github.com/ydb-platform/ydb-go-sql/trace"
 )
 
 type Option func(*connector)
@@ -93,3 +97,51 @@ func WithDefaultExecScanQueryOption(opts ...options.ExecuteScanQueryOption) Opti
 		c.scanOpts = append(c.scanOpts, opts...)
 	}
 }
+
+func WithPositionalArgs() Option {
+	return func(c *connector) {
+		c.queryOpts = append(c.queryOpts, query.WithPositionalArgs())
+	}
+}
+
+func WithAutoDeclare() Option {
+	return func(c *connector) {
+		c.queryOpts = append(c.queryOpts, query.WithAutoDeclare())
+	}
+}
+
+func WithValidateParams() Option {
+	return func(c *connector) {
+		c.queryOpts = append(c.queryOpts, query.WithValidate())
+	}
+}
+
+func WithTablePathPrefix(prefix string) Option {
+	return func(c *connector) {
+		c.queryOpts = append(c.queryOpts, query.WithTablePathPrefix(prefix))
+	}
+}
+
+func WithQueryTag(tag string) Option {
+	return func(c *connector) {
+		c.queryOpts = append(c.queryOpts, query.WithTag(tag))
+	}
+}
+
+func WithQueryStage(stage query.Stage) Option {
+	return func(c *connector) {
+		c.queryOpts = append(c.queryOpts, query.WithStage(stage))
+	}
+}
+
+func WithTraceSQL(t sqltrace.Trace) Option {
+	return func(c *connector) {
+		c.trace = c.trace.Compose(t)
+	}
+}
+
+func WithLog(l querylog.Logger, opts ...querylog.Option) Option {
+	return func(c *connector) {
+		c.log = querylog.New(l, opts...)
+	}
+}
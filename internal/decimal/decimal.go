@@ -0,0 +1,156 @@
+package decimal
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/types"
+)
+
+const (
+	// DefaultPrecision and DefaultScale are used for values scanned into
+	// zero Decimal from sources without precision and scale.
+	DefaultPrecision = 22
+	DefaultScale     = 9
+
+	maxPrecision = 35
+)
+
+// Decimal is a number of YDB Decimal(Precision,Scale) type.
+type Decimal struct {
+	// Unscaled is unscaled value of number, i.e. number equals to
+	// Unscaled * 10^-Scale.
+	Unscaled  *big.Int
+	Precision uint32
+	Scale     uint32
+}
+
+var ten = big.NewInt(10)
+
+func pow10(n uint32) *big.Int {
+	return new(big.Int).Exp(ten, big.NewInt(int64(n)), nil)
+}
+
+func check(v *big.Int, precision, scale uint32) error {
+	if precision == 0 || precision > maxPrecision || scale > precision {
+		return fmt.Errorf("ydb: invalid decimal type Decimal(%d,%d)", precision, scale)
+	}
+	if new(big.Int).Abs(v).Cmp(pow10(precision)) >= 0 {
+		return fmt.Errorf("ydb: decimal value is out of range of Decimal(%d,%d)", precision, scale)
+	}
+	return nil
+}
+
+// FromRat returns Decimal of r with given precision and scale. It returns
+// error if r could not be represented with scale digits after decimal point
+// or does not fit precision.
+func FromRat(r *big.Rat, precision, scale uint32) (Decimal, error) {
+	v := new(big.Rat).Mul(r, new(big.Rat).SetInt(pow10(scale)))
+	if !v.IsInt() {
+		return Decimal{}, fmt.Errorf("ydb: decimal %s has more than %d digits after decimal point", r.RatString(), scale)
+	}
+	d := Decimal{
+		Unscaled:  new(big.Int).Set(v.Num()),
+		Precision: precision,
+		Scale:     scale,
+	}
+	if err := check(d.Unscaled, precision, scale); err != nil {
+		return Decimal{}, err
+	}
+	return d, nil
+}
+
+// FromString parses decimal number s, e.g. "-12.345", and returns Decimal of
+// it with given precision and scale.
+func FromString(s string, precision, scale uint32) (Decimal, error) {
+	r, ok := new(big.Rat).SetString(s)
+	if !ok || strings.ContainsAny(s, "/eE") {
+		return Decimal{}, fmt.Errorf("ydb: invalid decimal %q", s)
+	}
+	return FromRat(r, precision, scale)
+}
+
+// FromYDB returns Decimal of value scanned from YDB.
+func FromYDB(d types.Decimal) Decimal {
+	return Decimal{
+		Unscaled:  d.BigInt(),
+		Precision: d.Precision,
+		Scale:     d.Scale,
+	}
+}
+
+func (d Decimal) unscaled() *big.Int {
+	if d.Unscaled == nil {
+		return new(big.Int)
+	}
+	return d.Unscaled
+}
+
+// Rat returns d as rational number.
+func (d Decimal) Rat() *big.Rat {
+	return new(big.Rat).SetFrac(d.unscaled(), pow10(d.Scale))
+}
+
+// String returns d in decimal notation with Scale digits after decimal
+// point, e.g. "-12.340" for Decimal(5,3).
+func (d Decimal) String() string {
+	v := d.unscaled()
+	s := new(big.Int).Abs(v).String()
+	if d.Scale > 0 {
+		if n := int(d.Scale) + 1 - len(s); n > 0 {
+			s = strings.Repeat("0", n) + s
+		}
+		p := len(s) - int(d.Scale)
+		s = s[:p] + "." + s[p:]
+	}
+	if v.Sign() < 0 {
+		s = "-" + s
+	}
+	return s
+}
+
+// Value implements driver.Valuer interface.
+func (d Decimal) Value() (driver.Value, error) {
+	if err := check(d.unscaled(), d.Precision, d.Scale); err != nil {
+		return nil, err
+	}
+	return types.DecimalValueFromBigInt(d.unscaled(), d.Precision, d.Scale), nil
+}
+
+// Scan implements sql.Scanner interface. Sources of string, []byte, int64
+// and float64 types are converted with precision and scale of d, or with
+// DefaultPrecision and DefaultScale if d is zero.
+func (d *Decimal) Scan(src interface{}) (err error) {
+	precision, scale := d.Precision, d.Scale
+	if precision == 0 {
+		precision, scale = DefaultPrecision, DefaultScale
+	}
+	switch x := src.(type) {
+	case Decimal:
+		*d = Decimal{
+			Unscaled:  new(big.Int).Set(x.unscaled()),
+			Precision: x.Precision,
+			Scale:     x.Scale,
+		}
+		return nil
+	case string:
+		*d, err = FromString(x, precision, scale)
+	case []byte:
+		*d, err = FromString(string(x), precision, scale)
+	case int64:
+		*d, err = FromRat(new(big.Rat).SetInt64(x), precision, scale)
+	case float64:
+		r := new(big.Rat)
+		if r.SetFloat64(x) == nil {
+			return fmt.Errorf("ydb: invalid decimal %v", x)
+		}
+		*d, err = FromRat(r, precision, scale)
+	case nil:
+		return fmt.Errorf("ydb: cannot scan NULL into decimal, use *Decimal instead")
+	default:
+		return fmt.Errorf("ydb: cannot scan %T into decimal", src)
+	}
+	return err
+}
@@ -0,0 +1,80 @@
+package decimal
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestFromString(t *testing.T) {
+	for _, test := range []struct {
+		s         string
+		precision uint32
+		scale     uint32
+		exp       string
+		err       bool
+	}{
+		{s: "12.345", precision: 22, scale: 9, exp: "12.345000000"},
+		{s: "-0.05", precision: 5, scale: 3, exp: "-0.050"},
+		{s: "42", precision: 2, scale: 0, exp: "42"},
+		{s: "0", precision: 22, scale: 9, exp: "0.000000000"},
+		{s: "0.0001", precision: 5, scale: 3, err: true},
+		{s: "100", precision: 2, scale: 0, err: true},
+		{s: "1e3", precision: 22, scale: 9, err: true},
+		{s: "1/3", precision: 22, scale: 9, err: true},
+		{s: "foo", precision: 22, scale: 9, err: true},
+		{s: "1", precision: 36, scale: 9, err: true},
+	} {
+		t.Run(test.s, func(t *testing.T) {
+			d, err := FromString(test.s, test.precision, test.scale)
+			if test.err {
+				if err == nil {
+					t.Fatalf("expected error, got %s", d)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if act := d.String(); act != test.exp {
+				t.Fatalf("unexpected string: %q; want %q", act, test.exp)
+			}
+			back, err := FromString(d.String(), test.precision, test.scale)
+			if err != nil || back.Unscaled.Cmp(d.Unscaled) != 0 {
+				t.Fatalf("string round-trip failed: %s (%v)", back, err)
+			}
+		})
+	}
+}
+
+func TestScan(t *testing.T) {
+	var d Decimal
+	if err := d.Scan("1.5"); err != nil {
+		t.Fatal(err)
+	}
+	if d.Precision != DefaultPrecision || d.Scale != DefaultScale {
+		t.Fatalf("unexpected type: Decimal(%d,%d)", d.Precision, d.Scale)
+	}
+	if d.Rat().Cmp(big.NewRat(3, 2)) != 0 {
+		t.Fatalf("unexpected value: %s", d)
+	}
+
+	d = Decimal{Precision: 5, Scale: 2}
+	if err := d.Scan(int64(7)); err != nil {
+		t.Fatal(err)
+	}
+	if act, exp := d.String(), "7.00"; act != exp {
+		t.Fatalf("unexpected value: %q; want %q", act, exp)
+	}
+
+	src := Decimal{Unscaled: big.NewInt(-1234), Precision: 10, Scale: 3}
+	if err := d.Scan(src); err != nil {
+		t.Fatal(err)
+	}
+	if act, exp := d.String(), "-1.234"; act != exp || d.Precision != 10 {
+		t.Fatalf("unexpected value: %q (precision %d); want %q", act, d.Precision, exp)
+	}
+
+	if err := d.Scan(nil); err == nil {
+		t.Fatal("expected error on scan of NULL")
+	}
+}
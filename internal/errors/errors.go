@@ -16,6 +16,9 @@ var (
 	ErrResultTruncated     = errors.New("ydb: result set has been truncated")
 	ErrWrongTxIsolation    = errors.New("ydb: wrong tx isolation")
 	ErrExecOnReadOnlyTx    = errors.New("ydb: cannot execute query on read-only tx")
+	ErrTxCommitted         = errors.New("ydb: tx already committed")
+	ErrTxRolledBack        = errors.New("ydb: tx already rolled back")
+	ErrTxBroken            = errors.New("ydb: tx is broken")
 
 	// Deprecated: not used
 	ErrSessionBusy = errors.New("ydb: session is busy")
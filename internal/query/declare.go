@@ -0,0 +1,76 @@
+package query
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"regexp"
+	"strings"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/types"
+
+	"github.com/ydb-platform/ydb-go-sql/internal/x"
+)
+
+var declareRe = regexp.MustCompile(`(?i)\bDECLARE\s+\$(\w+)`)
+
+// Declare prepends query with DECLARE statements for parameters of args which
+// are not declared in query yet. Args are expected to be checked by
+// check.NamedValue and bound: names start with $ and values are types.Value.
+// Args of other value types are skipped.
+func Declare(query string, args []driver.NamedValue) string {
+	var (
+		declared = Declared(query)
+		d        strings.Builder
+	)
+	for _, arg := range args {
+		name := strings.TrimPrefix(arg.Name, "$")
+		v, ok := arg.Value.(types.Value)
+		if !ok || name == "" || declared[name] {
+			continue
+		}
+		declared[name] = true
+		d.WriteString(x.Declare(name, v.ToYDB().GetType()))
+	}
+	if d.String() == "" {
+		return query
+	}
+	return d.String() + query
+}
+
+// Declared returns names (without $) of parameters declared in query.
+// Declarations within string literals and comments are ignored.
+func Declared(query string) map[string]bool {
+	declared := make(map[string]bool)
+	for _, m := range declareRe.FindAllStringSubmatch(Strip(query), -1) {
+		declared[m[1]] = true
+	}
+	return declared
+}
+
+// Strip returns query with string literals, quoted identifiers and comments
+// replaced by spaces, so positions within query are kept.
+func Strip(query string) string {
+	var (
+		b = []byte(query)
+		i int
+	)
+	blank := func(from, to int) {
+		copy(b[from:to], bytes.Repeat([]byte{' '}, to-from))
+		i = to
+	}
+	for i < len(query) {
+		switch c := query[i]; {
+		case c == '\'' || c == '"' || c == '`':
+			blank(i, skipQuoted(query, i, c))
+		case strings.HasPrefix(query[i:], "@@"):
+			blank(i, skipRaw(query, i))
+		case strings.HasPrefix(query[i:], "--"):
+			blank(i, skipUntil(query, i+2, "\n"))
+		case strings.HasPrefix(query[i:], "/*"):
+			blank(i, skipUntil(query, i+2, "*/"))
+		default:
+			i++
+		}
+	}
+	return string(b)
+}
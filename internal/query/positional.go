@@ -0,0 +1,130 @@
+package query
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Positional rewrites positional placeholders ? and $N of query into $pN
+// named parameters and returns the number of positional parameters. The ?
+// placeholders are numbered in order of appearance starting from one.
+// Placeholders within string literals, quoted identifiers and comments are
+// left as is. A ? right after an identifier or closing angle bracket is
+// treated as YQL optional type suffix (as in Int32? or List<Int32>?), so
+// comparison with placeholder should be written as a > ?, not a>?.
+//
+// Mixing ? and $N placeholders within a query is an error.
+func Positional(query string) (_ string, n int, err error) {
+	var (
+		b         strings.Builder
+		question  bool
+		numbered  bool
+		i, copied int
+	)
+	for i < len(query) {
+		switch c := query[i]; {
+		case c == '\'' || c == '"' || c == '`':
+			i = skipQuoted(query, i, c)
+
+		case strings.HasPrefix(query[i:], "@@"):
+			i = skipRaw(query, i)
+
+		case strings.HasPrefix(query[i:], "--"):
+			i = skipUntil(query, i+2, "\n")
+
+		case strings.HasPrefix(query[i:], "/*"):
+			i = skipUntil(query, i+2, "*/")
+
+		case c == '?' && !typeSuffix(query, i):
+			question = true
+			n++
+			b.WriteString(query[copied:i])
+			b.WriteString(Param(n))
+			i++
+			copied = i
+
+		case c == '$' && (i == 0 || !isIdent(query[i-1])):
+			j := i + 1
+			for j < len(query) && isDigit(query[j]) {
+				j++
+			}
+			if j == i+1 || j < len(query) && isIdent(query[j]) {
+				i++
+				continue
+			}
+			k, err := strconv.Atoi(query[i+1 : j])
+			if err != nil || k == 0 {
+				return "", 0, fmt.Errorf("ydb: invalid placeholder %s", query[i:j])
+			}
+			numbered = true
+			if k > n {
+				n = k
+			}
+			b.WriteString(query[copied:i])
+			b.WriteString(Param(k))
+			i = j
+			copied = i
+
+		default:
+			i++
+		}
+	}
+	if question && numbered {
+		return "", 0, fmt.Errorf("ydb: mixed ? and $N placeholders in query")
+	}
+	if copied == 0 {
+		return query, n, nil
+	}
+	b.WriteString(query[copied:])
+	return b.String(), n, nil
+}
+
+// skipQuoted returns position after string literal or quoted identifier
+// started at i with quote q.
+func skipQuoted(query string, i int, q byte) int {
+	for i++; i < len(query); i++ {
+		switch query[i] {
+		case '\\':
+			i++
+		case q:
+			return i + 1
+		}
+	}
+	return len(query)
+}
+
+// skipRaw returns position after @@multiline string@@ started at i. Doubled
+// @@@@ within string stands for @@.
+func skipRaw(query string, i int) int {
+	for i += 2; i < len(query); i++ {
+		if !strings.HasPrefix(query[i:], "@@") {
+			continue
+		}
+		if strings.HasPrefix(query[i+2:], "@@") {
+			i += 3
+			continue
+		}
+		return i + 2
+	}
+	return len(query)
+}
+
+func skipUntil(query string, i int, end string) int {
+	if j := strings.Index(query[i:], end); j >= 0 {
+		return i + j + len(end)
+	}
+	return len(query)
+}
+
+func typeSuffix(query string, i int) bool {
+	return i > 0 && (isIdent(query[i-1]) || query[i-1] == '>')
+}
+
+func isDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
+
+func isIdent(c byte) bool {
+	return isDigit(c) || c == '_' || 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z'
+}
@@ -0,0 +1,29 @@
+package query
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/ydb-platform/ydb-go-sql/internal/x"
+)
+
+var prefixRe = regexp.MustCompile(`(?i)\bPRAGMA\s+TablePathPrefix\b`)
+
+// Prefix prepends query with PRAGMA TablePathPrefix statement unless query
+// contains it already.
+func Prefix(query, prefix string) string {
+	if prefixRe.MatchString(Strip(query)) {
+		return query
+	}
+	return x.TablePathPrefix(prefix) + query
+}
+
+// Tag prepends query with comment containing tags separated by spaces. Line
+// breaks within tags are replaced by spaces.
+func Tag(query string, tags ...string) string {
+	if len(tags) == 0 {
+		return query
+	}
+	tag := strings.NewReplacer("\r", " ", "\n", " ").Replace(strings.Join(tags, " "))
+	return "-- " + tag + "\n" + query
+}
@@ -1,11 +1,278 @@
 package query
 
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+	"strconv"
+
+	"github.com/ydb-platform/ydb-go-sql/internal/mode"
+	"github.com/ydb-platform/ydb-go-sql/internal/x"
+)
+
+// Querier prepares query text and arguments for execution by running them
+// through pipeline of stages.
 type Querier interface {
+	// Query runs query with args through pipeline before execution.
+	Query(ctx context.Context, query string, args []driver.NamedValue) (string, []driver.NamedValue, error)
+
+	// Prepare runs query through pipeline before preparing statement. Stages
+	// which need args are skipped.
+	Prepare(ctx context.Context, query string) (string, error)
+
+	// Bind binds args of prepared statement to parameters of rewritten query
+	// and validates them against parameters declared in query if validation
+	// is enabled.
+	Bind(ctx context.Context, query string, args []driver.NamedValue) ([]driver.NamedValue, error)
+}
+
+// Query is a query passing through pipeline.
+type Query struct {
+	// Text is a text of query.
+	Text string
+
+	// Args are query arguments. Args are converted into types.Value and
+	// named with $ prefix (positional args are bound to $pN parameters)
+	// before user stages. Args are nil when query is being prepared.
+	Args []driver.NamedValue
+
+	// Mode is a mode of query execution.
+	Mode mode.Type
+
+	// Prepare is true when query is being prepared.
+	Prepare bool
+}
+
+// Stage is a step of query pipeline. Stage modifies q in place.
+type Stage func(ctx context.Context, q *Query) error
+
+type Option func(*querier)
+
+// WithPositionalArgs enables rewriting of positional placeholders ? and $N
+// into $pN named parameters.
+func WithPositionalArgs() Option {
+	return func(q *querier) {
+		q.positional = true
+	}
 }
 
-func New() Querier {
-	return &querier{}
+// WithAutoDeclare enables automatic declaration of query parameters by types
+// of bound values.
+func WithAutoDeclare() Option {
+	return func(q *querier) {
+		q.declare = true
+	}
+}
+
+// WithValidate enables client-side validation of parameters declared in query
+// against bound args of data and scan queries.
+func WithValidate() Option {
+	return func(q *querier) {
+		q.validate = true
+	}
+}
+
+// WithTablePathPrefix enables prefixing of queries with PRAGMA
+// TablePathPrefix unless query contains it already.
+func WithTablePathPrefix(prefix string) Option {
+	return func(q *querier) {
+		q.prefix = prefix
+	}
+}
+
+// WithTag enables tagging of queries with comment containing tag. Tags from
+// context (see x.WithQueryTags) are appended to it.
+func WithTag(tag string) Option {
+	return func(q *querier) {
+		q.tags = append(q.tags, tag)
+	}
+}
+
+// WithStage appends user stage to pipeline. User stages run in order of
+// appending after placeholders rewriting and args binding and before
+// automatic declaration and validation of parameters.
+func WithStage(stage Stage) Option {
+	return func(q *querier) {
+		q.stages = append(q.stages, stage)
+	}
+}
+
+func New(opts ...Option) Querier {
+	q := &querier{}
+	for _, o := range opts {
+		o(q)
+	}
+	return q
 }
 
 type querier struct {
+	positional bool
+	declare    bool
+	validate   bool
+	prefix     string
+	tags       []string
+	stages     []Stage
+}
+
+// pipeline returns stages of querier in order of running.
+func (q *querier) pipeline() []Stage {
+	stages := make([]Stage, 0, len(q.stages)+6)
+	if q.positional {
+		stages = append(stages, positionalStage)
+	}
+	stages = append(stages, q.bindStage)
+	stages = append(stages, q.stages...)
+	stages = append(stages, q.declareStage)
+	if q.validate {
+		stages = append(stages, validateStage)
+	}
+	if q.prefix != "" {
+		stages = append(stages, q.prefixStage)
+	}
+	stages = append(stages, q.tagStage)
+	return stages
+}
+
+func (q *querier) run(ctx context.Context, query *Query) error {
+	for _, stage := range q.pipeline() {
+		if err := stage(ctx, query); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (q *querier) Query(ctx context.Context, query string, args []driver.NamedValue) (string, []driver.NamedValue, error) {
+	r := Query{
+		Text: query,
+		Args: args,
+		Mode: x.QueryMode(ctx),
+	}
+	if err := q.run(ctx, &r); err != nil {
+		return "", nil, err
+	}
+	return r.Text, r.Args, nil
+}
+
+func (q *querier) Prepare(ctx context.Context, query string) (string, error) {
+	r := Query{
+		Text:    query,
+		Mode:    x.QueryMode(ctx),
+		Prepare: true,
+	}
+	if err := q.run(ctx, &r); err != nil {
+		return "", err
+	}
+	return r.Text, nil
+}
+
+func (q *querier) Bind(ctx context.Context, query string, args []driver.NamedValue) ([]driver.NamedValue, error) {
+	args, err := q.bind(args)
+	if err != nil {
+		return nil, err
+	}
+	if q.validate {
+		if err = validate(x.QueryMode(ctx), query, args); err != nil {
+			return nil, err
+		}
+	}
+	return args, nil
+}
+
+func (q *querier) bind(args []driver.NamedValue) ([]driver.NamedValue, error) {
+	var (
+		bound []driver.NamedValue
+		n     int
+	)
+	for i, arg := range args {
+		if arg.Name != "" {
+			continue
+		}
+		if !q.positional {
+			return nil, fmt.Errorf(
+				"ydb: only named parameters are supported " +
+					"(use ydb.WithPositionalArgs() to enable positional parameters)",
+			)
+		}
+		if bound == nil {
+			bound = make([]driver.NamedValue, len(args))
+			copy(bound, args)
+		}
+		n++
+		bound[i].Name = Param(n)
+	}
+	if bound == nil {
+		return args, nil
+	}
+	return bound, nil
+}
+
+// Param returns name of parameter which n-th (starting from one) positional
+// argument is bound to. Named arguments are not counted.
+func Param(n int) string {
+	return "$p" + strconv.Itoa(n)
+}
+
+func positionalStage(_ context.Context, q *Query) (err error) {
+	var n int
+	q.Text, n, err = Positional(q.Text)
+	if err != nil || q.Prepare {
+		return err
+	}
+	var positional int
+	for _, arg := range q.Args {
+		if arg.Name == "" {
+			positional++
+		}
+	}
+	if positional != n {
+		return fmt.Errorf(
+			"ydb: query expects %d positional arguments, got %d", n, positional,
+		)
+	}
+	return nil
+}
+
+func (q *querier) bindStage(_ context.Context, r *Query) (err error) {
+	r.Args, err = q.bind(r.Args)
+	return err
+}
+
+func (q *querier) declareStage(ctx context.Context, r *Query) error {
+	if !r.Prepare && (q.declare || x.AutoDeclare(ctx)) {
+		r.Text = Declare(r.Text, r.Args)
+	}
+	return nil
+}
+
+// validateStage validates args of query. Args of prepared statements are
+// validated on binding (see Bind).
+func validateStage(_ context.Context, q *Query) error {
+	if q.Prepare {
+		return nil
+	}
+	return validate(q.Mode, q.Text, q.Args)
+}
+
+func validate(m mode.Type, query string, args []driver.NamedValue) error {
+	switch m {
+	case mode.DataQuery, mode.ScanQuery:
+		return Validate(query, x.ToQueryParams(args))
+	default:
+		return nil
+	}
+}
+
+func (q *querier) prefixStage(_ context.Context, r *Query) error {
+	r.Text = Prefix(r.Text, q.prefix)
+	return nil
+}
+
+func (q *querier) tagStage(ctx context.Context, r *Query) error {
+	tags := q.tags
+	if t := x.QueryTags(ctx); len(t) > 0 {
+		tags = append(append(make([]string, 0, len(tags)+len(t)), tags...), t...)
+	}
+	r.Text = Tag(r.Text, tags...)
+	return nil
 }
@@ -0,0 +1,304 @@
+package query
+
+import (
+	"context"
+	"database/sql/driver"
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/table"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/types"
+
+	"github.com/ydb-platform/ydb-go-sql/internal/x"
+)
+
+func TestPositional(t *testing.T) {
+	for _, test := range []struct {
+		name  string
+		query string
+		exp   string
+		n     int
+		err   bool
+	}{
+		{
+			name:  "no placeholders",
+			query: "SELECT * FROM series WHERE series_id = $id",
+			exp:   "SELECT * FROM series WHERE series_id = $id",
+		},
+		{
+			name:  "question marks",
+			query: "SELECT * FROM episodes WHERE series_id = ? AND season_id IN (?,?)",
+			exp:   "SELECT * FROM episodes WHERE series_id = $p1 AND season_id IN ($p2,$p3)",
+			n:     3,
+		},
+		{
+			name:  "numbered",
+			query: "SELECT $2, $1, $2",
+			exp:   "SELECT $p2, $p1, $p2",
+			n:     2,
+		},
+		{
+			name:  "literals and comments",
+			query: "SELECT '?', \"$1\", `?`, @@ ? @@@@ ? @@, 'it\\'s ?' -- ?\n/* $1 */ ?",
+			exp:   "SELECT '?', \"$1\", `?`, @@ ? @@@@ ? @@, 'it\\'s ?' -- ?\n/* $1 */ $p1",
+			n:     1,
+		},
+		{
+			name:  "optional types",
+			query: "DECLARE $x AS Int32?; DECLARE $y AS List<Int32>?; SELECT $x, $y, ?",
+			exp:   "DECLARE $x AS Int32?; DECLARE $y AS List<Int32>?; SELECT $x, $y, $p1",
+			n:     1,
+		},
+		{
+			name:  "named with digits",
+			query: "SELECT $x1, $1x",
+			exp:   "SELECT $x1, $1x",
+		},
+		{
+			name:  "mixed",
+			query: "SELECT ?, $1",
+			err:   true,
+		},
+		{
+			name:  "zero",
+			query: "SELECT $0",
+			err:   true,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			act, n, err := Positional(test.query)
+			if test.err {
+				if err == nil {
+					t.Fatalf("expected error, got %q", act)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if act != test.exp || n != test.n {
+				t.Fatalf("unexpected result: %q, %d; want %q, %d", act, n, test.exp, test.n)
+			}
+		})
+	}
+}
+
+func TestQuerier(t *testing.T) {
+	args := []driver.NamedValue{
+		{Ordinal: 1, Value: 1},
+		{Name: "$name", Ordinal: 2, Value: 2},
+		{Ordinal: 3, Value: 3},
+	}
+
+	if _, _, err := New().Query(context.Background(), "SELECT ?", args); err == nil {
+		t.Fatalf("expected error on positional args")
+	}
+	if _, _, err := New(WithPositionalArgs()).Query(context.Background(), "SELECT ?", args); err == nil {
+		t.Fatalf("expected error on arguments count mismatch")
+	}
+
+	query, act, err := New(WithPositionalArgs()).Query(context.Background(), "SELECT ?, $name, ?", args)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if exp := "SELECT $p1, $name, $p2"; query != exp {
+		t.Fatalf("unexpected query: %q; want %q", query, exp)
+	}
+	exp := []driver.NamedValue{
+		{Name: "$p1", Ordinal: 1, Value: 1},
+		{Name: "$name", Ordinal: 2, Value: 2},
+		{Name: "$p2", Ordinal: 3, Value: 3},
+	}
+	if !reflect.DeepEqual(act, exp) {
+		t.Fatalf("unexpected args: %v; want %v", act, exp)
+	}
+}
+
+func TestDeclare(t *testing.T) {
+	args := []driver.NamedValue{
+		{Name: "$id", Value: types.Uint64Value(1)},
+		{Name: "$title", Value: types.OptionalValue(types.UTF8Value("foo"))},
+		{Name: "$ids", Value: types.ListValue(types.Uint64Value(1))},
+	}
+	query := "DECLARE $id AS Uint64; -- DECLARE $ids AS List<Uint64>;\n" +
+		"SELECT * FROM series WHERE series_id = $id OR title = $title OR series_id IN $ids"
+	exp := "DECLARE $title AS \"Optional<Utf8>\";\n" +
+		"DECLARE $ids AS \"List<Uint64>\";\n" +
+		query
+	if act := Declare(query, args); act != exp {
+		t.Fatalf("unexpected query: %q; want %q", act, exp)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	params := func(opts ...table.ParameterOption) *table.QueryParameters {
+		return table.NewQueryParameters(opts...)
+	}
+	for _, test := range []struct {
+		name   string
+		query  string
+		params *table.QueryParameters
+		err    string
+	}{
+		{
+			name:  "no declarations",
+			query: "SELECT $id",
+		},
+		{
+			name: "matched",
+			query: "DECLARE $id AS uint64; DECLARE $title AS Utf8?;\n" +
+				"DECLARE $ids AS \"List<Uint64>\"; DECLARE $d AS Decimal(22, 9);\n" +
+				"DECLARE $s AS List<Struct<b: Optional<Text>, a: Int32>>;\n" +
+				"DECLARE $v AS Variant<Int32, Bytes>;\n" +
+				"SELECT 1",
+			params: params(
+				table.ValueParam("$id", types.Uint64Value(1)),
+				table.ValueParam("$title", types.NullValue(types.TypeUTF8)),
+				table.ValueParam("$ids", types.ListValue(types.Uint64Value(1))),
+				table.ValueParam("$d", types.DecimalValueFromBigInt(big.NewInt(1), 22, 9)),
+				table.ValueParam("$s", types.ListValue(types.StructValue(
+					types.StructFieldValue("a", types.Int32Value(1)),
+					types.StructFieldValue("b", types.NullValue(types.TypeUTF8)),
+				))),
+				table.ValueParam("$v", types.VariantValue(types.Int32Value(1), 0, types.Variant(
+					types.Tuple(types.TypeInt32, types.TypeString),
+				))),
+			),
+		},
+		{
+			name:  "unparsed type",
+			query: "DECLARE $id AS Foo<Bar>; SELECT 1",
+			params: params(
+				table.ValueParam("$id", types.Uint64Value(1)),
+			),
+		},
+		{
+			name:  "commented",
+			query: "-- DECLARE $id AS Uint64;\nSELECT 1",
+		},
+		{
+			name:  "missing",
+			query: "DECLARE $id AS Uint64; SELECT $id",
+			err:   "ydb: declared parameter $id is not bound",
+		},
+		{
+			name:  "mismatch",
+			query: "DECLARE $id AS Uint64; SELECT $id",
+			params: params(
+				table.ValueParam("$id", types.Int64Value(1)),
+			),
+			err: "ydb: parameter $id declared as Uint64 but bound value is Int64",
+		},
+		{
+			name:  "optional mismatch",
+			query: "DECLARE $id AS Optional<Uint64>; SELECT $id",
+			params: params(
+				table.ValueParam("$id", types.Uint64Value(1)),
+			),
+			err: "ydb: parameter $id declared as Optional<Uint64> but bound value is Uint64",
+		},
+		{
+			name:  "first mismatch",
+			query: "DECLARE $b AS Uint64; DECLARE $a AS Uint64; DECLARE $c AS Uint64; SELECT 1",
+			params: params(
+				table.ValueParam("$a", types.Int64Value(1)),
+				table.ValueParam("$b", types.Int32Value(1)),
+			),
+			err: "ydb: parameter $b declared as Uint64 but bound value is Int32",
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			err := Validate(test.query, test.params)
+			if test.err == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != test.err {
+				t.Fatalf("unexpected error: %v; want %q", err, test.err)
+			}
+		})
+	}
+}
+
+func TestPipeline(t *testing.T) {
+	var stages []string
+	q := New(
+		WithPositionalArgs(),
+		WithAutoDeclare(),
+		WithValidate(),
+		WithTablePathPrefix("/local"),
+		WithTag("app"),
+		WithStage(func(ctx context.Context, q *Query) error {
+			stages = append(stages, q.Text)
+			if q.Prepare {
+				return nil
+			}
+			q.Args = append(q.Args, driver.NamedValue{
+				Name:  "$limit",
+				Value: types.Uint64Value(10),
+			})
+			q.Text += " LIMIT $limit"
+			return nil
+		}),
+	)
+	ctx := x.WithQueryTags(context.Background(), []string{"handler"})
+
+	query, args, err := q.Query(ctx, "SELECT * FROM series WHERE series_id = ?", []driver.NamedValue{
+		{Ordinal: 1, Value: types.Uint64Value(1)},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	exp := "-- app handler\n" +
+		"PRAGMA TablePathPrefix(\"/local\");\n" +
+		"DECLARE $p1 AS \"Uint64\";\n" +
+		"DECLARE $limit AS \"Uint64\";\n" +
+		"SELECT * FROM series WHERE series_id = $p1 LIMIT $limit"
+	if query != exp {
+		t.Fatalf("unexpected query: %q; want %q", query, exp)
+	}
+	if len(args) != 2 || args[0].Name != "$p1" || args[1].Name != "$limit" {
+		t.Fatalf("unexpected args: %v", args)
+	}
+
+	query, err = q.Prepare(context.Background(), "PRAGMA TablePathPrefix(\"/other\"); SELECT ?")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if exp = "-- app\nPRAGMA TablePathPrefix(\"/other\"); SELECT $p1"; query != exp {
+		t.Fatalf("unexpected query: %q; want %q", query, exp)
+	}
+
+	if exp := []string{
+		"SELECT * FROM series WHERE series_id = $p1",
+		"PRAGMA TablePathPrefix(\"/other\"); SELECT $p1",
+	}; !reflect.DeepEqual(stages, exp) {
+		t.Fatalf("unexpected stages input: %q; want %q", stages, exp)
+	}
+}
+
+func TestBind(t *testing.T) {
+	q := New(WithPositionalArgs(), WithValidate())
+	query, err := q.Prepare(context.Background(), "DECLARE $p1 AS Uint64; SELECT ?")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	args, err := q.Bind(context.Background(), query, []driver.NamedValue{
+		{Ordinal: 1, Value: types.Uint64Value(1)},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(args) != 1 || args[0].Name != "$p1" {
+		t.Fatalf("unexpected args: %v", args)
+	}
+	_, err = q.Bind(context.Background(), query, []driver.NamedValue{
+		{Ordinal: 1, Value: types.Int64Value(1)},
+	})
+	if exp := "ydb: parameter $p1 declared as Uint64 but bound value is Int64"; err == nil || err.Error() != exp {
+		t.Fatalf("unexpected error: %v; want %q", err, exp)
+	}
+}
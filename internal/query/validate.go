@@ -0,0 +1,266 @@
+package query
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/table"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/types"
+
+	"github.com/ydb-platform/ydb-go-sql/internal/x"
+)
+
+var declarationRe = regexp.MustCompile(`(?i)\bDECLARE\s+\$(\w+)\s+AS\s+`)
+
+// Declaration is a declaration of query parameter.
+type Declaration struct {
+	// Name is a name of parameter without $.
+	Name string
+
+	// Type is a declared type normalized as x.TypeString does. Type is
+	// empty if it could not be parsed.
+	Type string
+}
+
+// Declarations returns parameters declared in query in order of declaration.
+func Declarations(query string) (declared []Declaration) {
+	stripped := Strip(query)
+	for _, m := range declarationRe.FindAllStringSubmatchIndex(stripped, -1) {
+		d := Declaration{Name: query[m[2]:m[3]]}
+		text := query[m[1]:]
+		if text != "" && (text[0] == '"' || text[0] == '\'') {
+			end := skipQuoted(text, 0, text[0])
+			text = strings.TrimSuffix(text[1:end], text[:1])
+		} else if end := strings.IndexByte(stripped[m[1]:], ';'); end >= 0 {
+			text = text[:end]
+		}
+		d.Type, _ = normalize(text)
+		declared = append(declared, d)
+	}
+	return declared
+}
+
+// Validate checks that every parameter declared in query is bound in params
+// with value of declared type. Parameters are checked in order of
+// declaration, so error is reported for the first mismatched one.
+func Validate(query string, params *table.QueryParameters) error {
+	declared := Declarations(query)
+	if len(declared) == 0 {
+		return nil
+	}
+	bound := make(map[string]string)
+	if params != nil {
+		params.Each(func(name string, v types.Value) {
+			t := x.TypeString(v.ToYDB().GetType())
+			if n, err := normalize(t); err == nil {
+				t = n
+			}
+			bound[strings.TrimPrefix(name, "$")] = t
+		})
+	}
+	for _, d := range declared {
+		b, ok := bound[d.Name]
+		switch {
+		case !ok:
+			return fmt.Errorf("ydb: declared parameter $%s is not bound", d.Name)
+		case d.Type != "" && d.Type != b:
+			return fmt.Errorf(
+				"ydb: parameter $%s declared as %s but bound value is %s", d.Name, d.Type, b,
+			)
+		}
+	}
+	return nil
+}
+
+// typeAliases maps lower-cased YQL type names to canonical ones.
+var typeAliases = map[string]string{
+	"bool":         "Bool",
+	"int8":         "Int8",
+	"uint8":        "Uint8",
+	"int16":        "Int16",
+	"uint16":       "Uint16",
+	"int32":        "Int32",
+	"uint32":       "Uint32",
+	"int64":        "Int64",
+	"uint64":       "Uint64",
+	"float":        "Float",
+	"double":       "Double",
+	"date":         "Date",
+	"datetime":     "Datetime",
+	"timestamp":    "Timestamp",
+	"interval":     "Interval",
+	"tzdate":       "TzDate",
+	"tzdatetime":   "TzDatetime",
+	"tztimestamp":  "TzTimestamp",
+	"string":       "String",
+	"bytes":        "String",
+	"utf8":         "Utf8",
+	"text":         "Utf8",
+	"yson":         "Yson",
+	"json":         "Json",
+	"uuid":         "Uuid",
+	"jsondocument": "JsonDocument",
+	"dynumber":     "DyNumber",
+	"void":         "Void",
+	"decimal":      "Decimal",
+	"optional":     "Optional",
+	"list":         "List",
+	"tuple":        "Tuple",
+	"struct":       "Struct",
+	"dict":         "Dict",
+	"variant":      "Variant",
+}
+
+// normalize parses YQL type text and returns it in form of x.TypeString.
+func normalize(text string) (string, error) {
+	p := typeParser{s: text}
+	var b strings.Builder
+	p.parseType(&b)
+	p.skipSpace()
+	if p.err == nil && p.i < len(p.s) {
+		p.fail()
+	}
+	if p.err != nil {
+		return "", p.err
+	}
+	return b.String(), nil
+}
+
+type typeParser struct {
+	s   string
+	i   int
+	err error
+}
+
+func (p *typeParser) fail() {
+	if p.err == nil {
+		p.err = fmt.Errorf("ydb: cannot parse type %q at %d", p.s, p.i)
+	}
+}
+
+func (p *typeParser) skipSpace() {
+	for p.i < len(p.s) && strings.IndexByte(" \t\r\n", p.s[p.i]) >= 0 {
+		p.i++
+	}
+}
+
+func (p *typeParser) accept(c byte) bool {
+	p.skipSpace()
+	if p.i < len(p.s) && p.s[p.i] == c {
+		p.i++
+		return true
+	}
+	return false
+}
+
+func (p *typeParser) expect(c byte) {
+	if !p.accept(c) {
+		p.fail()
+	}
+}
+
+func (p *typeParser) ident() string {
+	p.skipSpace()
+	start := p.i
+	for p.i < len(p.s) && isIdent(p.s[p.i]) {
+		p.i++
+	}
+	if start == p.i {
+		p.fail()
+	}
+	return p.s[start:p.i]
+}
+
+func (p *typeParser) parseType(b *strings.Builder) {
+	var t strings.Builder
+	name, ok := typeAliases[strings.ToLower(p.ident())]
+	if !ok {
+		p.fail()
+		return
+	}
+	t.WriteString(name)
+	switch name {
+	case "Decimal":
+		p.expect('(')
+		t.WriteByte('(')
+		t.WriteString(p.ident())
+		p.expect(',')
+		t.WriteByte(',')
+		t.WriteString(p.ident())
+		p.expect(')')
+		t.WriteByte(')')
+	case "Optional", "List":
+		p.expect('<')
+		t.WriteByte('<')
+		p.parseType(&t)
+		p.expect('>')
+		t.WriteByte('>')
+	case "Tuple", "Dict":
+		p.expect('<')
+		t.WriteByte('<')
+		p.parseTypes(&t)
+		p.expect('>')
+		t.WriteByte('>')
+	case "Struct", "Variant":
+		p.expect('<')
+		t.WriteByte('<')
+		p.skipSpace()
+		if name == "Variant" && p.i < len(p.s) && p.lookaheadType() {
+			p.parseTypes(&t)
+		} else {
+			p.parseMembers(&t)
+		}
+		p.expect('>')
+		t.WriteByte('>')
+	}
+	s := t.String()
+	for p.accept('?') {
+		s = "Optional<" + s + ">"
+	}
+	b.WriteString(s)
+}
+
+// lookaheadType returns true if next item of Variant is a type, not a member.
+func (p *typeParser) lookaheadType() bool {
+	j := p.i
+	for j < len(p.s) && isIdent(p.s[j]) {
+		j++
+	}
+	for j < len(p.s) && p.s[j] == ' ' {
+		j++
+	}
+	return j >= len(p.s) || p.s[j] != ':'
+}
+
+func (p *typeParser) parseTypes(b *strings.Builder) {
+	for i := 0; p.err == nil; i++ {
+		if i > 0 {
+			if !p.accept(',') {
+				return
+			}
+			b.WriteByte(',')
+		}
+		p.parseType(b)
+	}
+}
+
+// parseMembers parses struct members and writes them sorted by name as YDB
+// does not distinguish struct types by order of members.
+func (p *typeParser) parseMembers(b *strings.Builder) {
+	var members []string
+	for i := 0; p.err == nil; i++ {
+		if i > 0 && !p.accept(',') {
+			break
+		}
+		var m strings.Builder
+		m.WriteString(p.ident())
+		p.expect(':')
+		m.WriteByte(':')
+		p.parseType(&m)
+		members = append(members, m.String())
+	}
+	sort.Strings(members)
+	b.WriteString(strings.Join(members, ","))
+}
@@ -0,0 +1,236 @@
+package querylog
+
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/types"
+
+	"github.com/ydb-platform/ydb-go-sql/internal/scan"
+)
+
+// Level is a severity of log record. Values are the same as of log/slog
+// levels.
+type Level int
+
+const (
+	LevelDebug Level = -4
+	LevelInfo  Level = 0
+	LevelWarn  Level = 4
+	LevelError Level = 8
+)
+
+func (l Level) String() string {
+	switch l {
+	case LevelDebug:
+		return "DEBUG"
+	case LevelInfo:
+		return "INFO"
+	case LevelWarn:
+		return "WARN"
+	case LevelError:
+		return "ERROR"
+	default:
+		return fmt.Sprintf("LEVEL(%d)", int(l))
+	}
+}
+
+// Logger writes structured log records. Args are alternating keys and values
+// as in log/slog.Logger.Log.
+type Logger interface {
+	Log(ctx context.Context, level Level, msg string, args ...interface{})
+}
+
+// LoggerFunc is an adapter of function to Logger.
+type LoggerFunc func(ctx context.Context, level Level, msg string, args ...interface{})
+
+func (f LoggerFunc) Log(ctx context.Context, level Level, msg string, args ...interface{}) {
+	f(ctx, level, msg, args...)
+}
+
+// Explain returns plan of query.
+type Explain func(ctx context.Context, query string) (plan string, err error)
+
+// Redacted replaces values of redacted parameters.
+const Redacted = "<redacted>"
+
+// explainTimeout bounds explanation of slow query.
+const explainTimeout = 5 * time.Second
+
+type Option func(*Log)
+
+// WithLevel sets level of records of queries which are neither failed nor
+// slow. Default level is LevelInfo.
+func WithLevel(level Level) Option {
+	return func(l *Log) {
+		l.level = level
+	}
+}
+
+// WithSlowThreshold sets duration since which query is considered slow.
+// Slow queries are logged with LevelWarn. Zero threshold disables detection
+// of slow queries.
+func WithSlowThreshold(threshold time.Duration) Option {
+	return func(l *Log) {
+		l.threshold = threshold
+	}
+}
+
+// WithExplain enables attaching plan of query to records of slow queries
+// which succeeded. Query is explained synchronously before record is logged,
+// so it delays return of slow query.
+func WithExplain() Option {
+	return func(l *Log) {
+		l.explain = true
+	}
+}
+
+// WithRedact sets pattern of names of parameters which values are replaced
+// with Redacted. Names are matched without $ prefix.
+func WithRedact(pattern *regexp.Regexp) Option {
+	return func(l *Log) {
+		l.redact = pattern
+	}
+}
+
+// Log logs executed queries and finished transactions into logger.
+type Log struct {
+	logger    Logger
+	level     Level
+	threshold time.Duration
+	explain   bool
+	redact    *regexp.Regexp
+}
+
+func New(logger Logger, opts ...Option) *Log {
+	l := &Log{
+		logger: logger,
+		level:  LevelInfo,
+	}
+	for _, opt := range opts {
+		opt(l)
+	}
+	return l
+}
+
+// Entry describes logged operation.
+type Entry struct {
+	// Msg is a message of record, e.g. "ydb: query".
+	Msg string
+
+	Query    string
+	Mode     string
+	Args     []driver.NamedValue
+	TxID     string
+	Prepared bool
+
+	// Explain is called for slow operations which succeeded if it is not nil
+	// and explanation is enabled with WithExplain.
+	Explain Explain
+}
+
+// Start returns function which logs e with duration since Start call, number
+// of returned rows and error of operation. Rows are not logged if negative.
+// Only first call of returned function takes effect. Start on nil Log returns
+// function which does nothing.
+func (l *Log) Start(ctx context.Context, e Entry) func(rows int, err error) {
+	if l == nil {
+		return func(int, error) {}
+	}
+	var (
+		start = time.Now()
+		once  sync.Once
+	)
+	return func(rows int, err error) {
+		once.Do(func() {
+			l.log(ctx, e, time.Since(start), rows, err)
+		})
+	}
+}
+
+func (l *Log) log(ctx context.Context, e Entry, d time.Duration, rows int, err error) {
+	var (
+		level = l.level
+		slow  = l.threshold > 0 && d >= l.threshold
+		args  []interface{}
+	)
+	if e.Query != "" {
+		args = append(args, "query", e.Query)
+	}
+	if e.Mode != "" {
+		args = append(args, "mode", e.Mode)
+	}
+	if len(e.Args) > 0 {
+		args = append(args, "args", l.args(e.Args))
+	}
+	if e.TxID != "" {
+		args = append(args, "tx", e.TxID)
+	}
+	if e.Prepared {
+		args = append(args, "prepared", true)
+	}
+	args = append(args, "duration", d)
+	if rows >= 0 {
+		args = append(args, "rows", rows)
+	}
+	if slow {
+		level = LevelWarn
+		args = append(args, "slow", true)
+		if l.explain && e.Explain != nil && err == nil {
+			args = append(args, l.plan(e)...)
+		}
+	}
+	if err != nil {
+		level = LevelError
+		args = append(args, "error", err)
+	}
+	l.logger.Log(ctx, level, e.Msg, args...)
+}
+
+// plan returns plan of query of e as record args. Query is explained with
+// detached context as context of query could be done already.
+func (l *Log) plan(e Entry) []interface{} {
+	ctx, cancel := context.WithTimeout(context.Background(), explainTimeout)
+	defer cancel()
+	plan, err := e.Explain(ctx, e.Query)
+	if err != nil {
+		return []interface{}{"explain_error", err}
+	}
+	return []interface{}{"plan", plan}
+}
+
+// args returns values of parameters by names. Unnamed parameters are named
+// by ordinal, e.g. #1.
+func (l *Log) args(values []driver.NamedValue) map[string]interface{} {
+	m := make(map[string]interface{}, len(values))
+	for _, v := range values {
+		name := v.Name
+		if name == "" {
+			name = fmt.Sprintf("#%d", v.Ordinal)
+		}
+		if l.redact != nil && l.redact.MatchString(strings.TrimPrefix(name, "$")) {
+			m[name] = Redacted
+			continue
+		}
+		m[name] = value(v.Value)
+	}
+	return m
+}
+
+// value returns Go representation of parameter value.
+func value(v interface{}) interface{} {
+	tv, ok := v.(types.Value)
+	if !ok {
+		return v
+	}
+	y := tv.ToYDB()
+	if x, err := scan.Any(y.GetType(), y.GetValue()); err == nil {
+		return x
+	}
+	return y.String()
+}
@@ -0,0 +1,116 @@
+package querylog
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"reflect"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/types"
+)
+
+type record struct {
+	level Level
+	msg   string
+	args  map[string]interface{}
+}
+
+func TestLog(t *testing.T) {
+	var records []record
+	logger := LoggerFunc(func(_ context.Context, level Level, msg string, args ...interface{}) {
+		r := record{level: level, msg: msg, args: make(map[string]interface{})}
+		for i := 0; i < len(args); i += 2 {
+			if args[i] != "duration" {
+				r.args[args[i].(string)] = args[i+1]
+			}
+		}
+		records = append(records, r)
+	})
+	l := New(logger,
+		WithLevel(LevelDebug),
+		WithSlowThreshold(time.Hour),
+		WithExplain(),
+		WithRedact(regexp.MustCompile("^password$")),
+	)
+	ctx := context.Background()
+
+	onDone := l.Start(ctx, Entry{
+		Msg:   "ydb: query",
+		Query: "SELECT 1",
+		Mode:  "data_query",
+		Args: []driver.NamedValue{
+			{Name: "$login", Value: types.UTF8Value("user")},
+			{Name: "$password", Value: types.UTF8Value("secret")},
+			{Ordinal: 3, Value: types.Int32Value(42)},
+		},
+		TxID: "tx",
+	})
+	onDone(2, nil)
+	onDone(3, errors.New("ignored"))
+
+	fail := errors.New("fail")
+	l.Start(ctx, Entry{Msg: "ydb: commit", TxID: "tx"})(-1, fail)
+
+	l.threshold = time.Nanosecond
+	explain := func(ctx context.Context, query string) (string, error) {
+		if ctx.Err() != nil {
+			return "", ctx.Err()
+		}
+		return "plan of " + query, nil
+	}
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	l.Start(canceled, Entry{
+		Msg:     "ydb: exec",
+		Query:   "UPSERT",
+		Mode:    "data_query",
+		Explain: explain,
+	})(-1, nil)
+	l.Start(ctx, Entry{
+		Msg:     "ydb: exec",
+		Query:   "DELETE",
+		Mode:    "data_query",
+		Explain: explain,
+	})(-1, fail)
+
+	exp := []record{
+		{LevelDebug, "ydb: query", map[string]interface{}{
+			"query": "SELECT 1",
+			"mode":  "data_query",
+			"args": map[string]interface{}{
+				"$login":    "user",
+				"$password": Redacted,
+				"#3":        int64(42),
+			},
+			"tx":   "tx",
+			"rows": 2,
+		}},
+		{LevelError, "ydb: commit", map[string]interface{}{
+			"tx":    "tx",
+			"error": fail,
+		}},
+		{LevelWarn, "ydb: exec", map[string]interface{}{
+			"query": "UPSERT",
+			"mode":  "data_query",
+			"slow":  true,
+			"plan":  "plan of UPSERT",
+		}},
+		{LevelError, "ydb: exec", map[string]interface{}{
+			"query": "DELETE",
+			"mode":  "data_query",
+			"slow":  true,
+			"error": fail,
+		}},
+	}
+	if !reflect.DeepEqual(records, exp) {
+		t.Fatalf("unexpected records:\n%+v\nwant:\n%+v", records, exp)
+	}
+}
+
+func TestNil(t *testing.T) {
+	var l *Log
+	l.Start(context.Background(), Entry{})(0, nil)
+}
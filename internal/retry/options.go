@@ -0,0 +1,67 @@
+package retry
+
+import (
+	"time"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/retry"
+)
+
+// Backoff is a delay policy between attempts. See retry.FastBackoff and
+// retry.SlowBackoff for defaults.
+type Backoff = retry.Backoff
+
+type Option func(*options)
+
+type options struct {
+	idempotent     *bool
+	maxAttempts    int
+	fastBackoff    Backoff
+	slowBackoff    Backoff
+	attemptTimeout time.Duration
+	onRetry        func(Info)
+}
+
+// WithIdempotent overrides the idempotency flag which is taken from context
+// by default (see retry.WithIdempotentOperation).
+func WithIdempotent(idempotent bool) Option {
+	return func(o *options) {
+		o.idempotent = &idempotent
+	}
+}
+
+// WithMaxAttempts limits the total number of attempts. Zero or negative value
+// means no limit (retries are bounded by context only).
+func WithMaxAttempts(maxAttempts int) Option {
+	return func(o *options) {
+		o.maxAttempts = maxAttempts
+	}
+}
+
+// WithFastBackoff replaces backoff used for errors which require fast backoff.
+func WithFastBackoff(b Backoff) Option {
+	return func(o *options) {
+		o.fastBackoff = b
+	}
+}
+
+// WithSlowBackoff replaces backoff used for errors which require slow backoff.
+func WithSlowBackoff(b Backoff) Option {
+	return func(o *options) {
+		o.slowBackoff = b
+	}
+}
+
+// WithAttemptTimeout bounds each attempt with its own timeout.
+func WithAttemptTimeout(timeout time.Duration) Option {
+	return func(o *options) {
+		o.attemptTimeout = timeout
+	}
+}
+
+// WithOnRetry sets callback which is called after each failed attempt which
+// is going to be retried.
+func WithOnRetry(onRetry func(Info)) Option {
+	return func(o *options) {
+		o.onRetry = onRetry
+	}
+}
@@ -0,0 +1,149 @@
+package retry
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/retry"
+)
+
+// Info describes failed attempt which is going to be retried.
+type Info struct {
+	// Attempt is a number of failed attempt starting from 1.
+	Attempt int
+	// Err is an error returned from failed attempt.
+	Err error
+	// Code is a ydb status code of Err or -1 if Err is not a ydb error.
+	Code int32
+	// BadConn reports that Err is driver.ErrBadConn and next attempt will be
+	// made on another connection.
+	BadConn bool
+}
+
+type ctxAttemptKey struct{}
+
+// Attempt returns number of attempt starting from 1 which ctx of op call
+// belongs to or 0 if ctx is not a context of op call.
+func Attempt(ctx context.Context) int {
+	attempt, _ := ctx.Value(ctxAttemptKey{}).(int)
+	return attempt
+}
+
+// notExecutedError marks error of attempt which failed before any query of
+// op has been sent, e.g. on getting connection or beginning transaction.
+type notExecutedError struct {
+	err error
+}
+
+func (e notExecutedError) Error() string { return e.err.Error() }
+func (e notExecutedError) Unwrap() error { return e.err }
+
+// NotExecuted wraps err to report that attempt failed before execution of op,
+// so it is safe to retry it even if op is not idempotent. Retry returns
+// unwrapped err.
+func NotExecuted(err error) error {
+	if err == nil {
+		return nil
+	}
+	return notExecutedError{err: err}
+}
+
+// Retry calls op until it returns nil, non-retryable error, attempts limit
+// reached or context is done.
+// Retryable errors are classified with retry.Check. Additionally,
+// driver.ErrBadConn is retried if op is idempotent or attempt failed before
+// execution (see NotExecuted) because database/sql guarantees that bad
+// connection will not be reused on next attempt. Otherwise driver.ErrBadConn
+// could be returned when the outcome of op is unknown (e.g. on transport
+// error during commit), so it is not retried.
+func Retry(ctx context.Context, op func(context.Context) error, opts ...Option) (err error) {
+	o := options{
+		fastBackoff: retry.FastBackoff,
+		slowBackoff: retry.SlowBackoff,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	idempotent := retry.IsOperationIdempotent(ctx)
+	if o.idempotent != nil {
+		idempotent = *o.idempotent
+	}
+	var (
+		i    int
+		code = int32(0)
+	)
+	for attempt := 1; ; attempt++ {
+		i++
+		select {
+		case <-ctx.Done():
+			if err != nil {
+				return err
+			}
+			return ctx.Err()
+		default:
+		}
+		err = o.do(context.WithValue(ctx, ctxAttemptKey{}, attempt), op)
+		if err == nil {
+			return nil
+		}
+		var notExecuted notExecutedError
+		if errors.As(err, &notExecuted) {
+			err = notExecuted.err
+		}
+		var (
+			m       = retry.Check(err)
+			badConn = errors.Is(err, driver.ErrBadConn)
+		)
+		if m.StatusCode() != code {
+			i = 0
+		}
+		if badConn {
+			if !idempotent && notExecuted.err == nil {
+				return err
+			}
+		} else if !m.MustRetry(idempotent) {
+			return err
+		}
+		if o.maxAttempts > 0 && attempt >= o.maxAttempts {
+			return err
+		}
+		if o.onRetry != nil {
+			o.onRetry(Info{
+				Attempt: attempt,
+				Err:     err,
+				Code:    m.StatusCode(),
+				BadConn: badConn,
+			})
+		}
+		if badConn {
+			if e := wait(ctx, o.fastBackoff, i); e != nil {
+				return err
+			}
+		} else if e := retry.Wait(ctx, o.fastBackoff, o.slowBackoff, m, i); e != nil {
+			return err
+		}
+		code = m.StatusCode()
+	}
+}
+
+func (o options) do(ctx context.Context, op func(context.Context) error) error {
+	if o.attemptTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, o.attemptTimeout)
+		defer cancel()
+	}
+	return op(ctx)
+}
+
+func wait(ctx context.Context, b retry.Backoff, i int) error {
+	if b == nil {
+		return ctx.Err()
+	}
+	select {
+	case <-b.Wait(i):
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,129 @@
+package retry
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"testing"
+	"time"
+)
+
+type noBackoff struct{}
+
+func (noBackoff) Wait(int) <-chan time.Time {
+	ch := make(chan time.Time, 1)
+	ch <- time.Now()
+	return ch
+}
+
+func TestRetry(t *testing.T) {
+	errOther := errors.New("other")
+	for _, test := range []struct {
+		name     string
+		errs     []error
+		opts     []Option
+		attempts int
+		retries  int
+		err      error
+	}{
+		{
+			name:     "success",
+			errs:     []error{nil},
+			attempts: 1,
+		},
+		{
+			name:     "bad conn",
+			errs:     []error{driver.ErrBadConn, driver.ErrBadConn, nil},
+			opts:     []Option{WithIdempotent(true)},
+			attempts: 3,
+			retries:  2,
+		},
+		{
+			name:     "bad conn before execution",
+			errs:     []error{NotExecuted(driver.ErrBadConn), nil},
+			opts:     []Option{WithIdempotent(false)},
+			attempts: 2,
+			retries:  1,
+		},
+		{
+			// Commit could be applied by server before connection has
+			// been broken, so non-idempotent operation must not be repeated.
+			name:     "bad conn after commit",
+			errs:     []error{driver.ErrBadConn, nil},
+			opts:     []Option{WithIdempotent(false)},
+			attempts: 1,
+			err:      driver.ErrBadConn,
+		},
+		{
+			name:     "non-retryable",
+			errs:     []error{errOther, nil},
+			attempts: 1,
+			err:      errOther,
+		},
+		{
+			name:     "max attempts",
+			errs:     []error{NotExecuted(driver.ErrBadConn), NotExecuted(driver.ErrBadConn), nil},
+			opts:     []Option{WithMaxAttempts(2)},
+			attempts: 2,
+			retries:  1,
+			err:      driver.ErrBadConn,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			var (
+				attempts int
+				retries  int
+			)
+			err := Retry(context.Background(), func(ctx context.Context) error {
+				err := test.errs[attempts]
+				attempts++
+				if a := Attempt(ctx); a != attempts {
+					t.Fatalf("unexpected attempt in context: %d; want %d", a, attempts)
+				}
+				return err
+			}, append([]Option{
+				WithFastBackoff(noBackoff{}),
+				WithSlowBackoff(noBackoff{}),
+				WithOnRetry(func(info Info) {
+					retries++
+					if info.Attempt != retries {
+						t.Fatalf("unexpected attempt: %d; want %d", info.Attempt, retries)
+					}
+					if !info.BadConn {
+						t.Fatalf("unexpected retry of %v", info.Err)
+					}
+				}),
+			}, test.opts...)...)
+			if err != test.err {
+				t.Fatalf("unexpected error: %v; want %v", err, test.err)
+			}
+			if attempts != test.attempts {
+				t.Fatalf("unexpected attempts: %d; want %d", attempts, test.attempts)
+			}
+			if retries != test.retries {
+				t.Fatalf("unexpected retries: %d; want %d", retries, test.retries)
+			}
+		})
+	}
+}
+
+func TestRetryAttemptTimeout(t *testing.T) {
+	var attempts int
+	err := Retry(context.Background(), func(ctx context.Context) error {
+		attempts++
+		if attempts == 1 {
+			if _, ok := ctx.Deadline(); !ok {
+				t.Fatalf("no deadline in attempt context")
+			}
+			<-ctx.Done()
+			return driver.ErrBadConn
+		}
+		return nil
+	}, WithAttemptTimeout(10*time.Millisecond), WithFastBackoff(noBackoff{}), WithIdempotent(true))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if attempts != 2 {
+		t.Fatalf("unexpected attempts: %d; want 2", attempts)
+	}
+}
@@ -0,0 +1,40 @@
+package rows
+
+import (
+	"database/sql/driver"
+	"io"
+)
+
+// WithCount returns Rows which counts rows returned by r and calls done with
+// number of rows and first error of r on Close call.
+func WithCount(r driver.Rows, done func(rows int, err error)) Rows {
+	return &counted{wrapped: wrapped{r}, done: done}
+}
+
+type counted struct {
+	wrapped
+
+	n    int
+	err  error
+	done func(rows int, err error)
+}
+
+func (r *counted) Next(dst []driver.Value) error {
+	err := r.Rows.Next(dst)
+	switch {
+	case err == nil:
+		r.n++
+	case err != io.EOF && r.err == nil:
+		r.err = err
+	}
+	return err
+}
+
+func (r *counted) Close() error {
+	err := r.Rows.Close()
+	if r.err == nil {
+		r.err = err
+	}
+	r.done(r.n, r.err)
+	return err
+}
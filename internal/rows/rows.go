@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql/driver"
 	"io"
+	"reflect"
 
 	"github.com/ydb-platform/ydb-go-sdk/v3/table/options"
 	"github.com/ydb-platform/ydb-go-sdk/v3/table/result"
@@ -16,6 +17,10 @@ import (
 type Rows interface {
 	driver.Rows
 	driver.RowsNextResultSet
+	driver.RowsColumnTypeDatabaseTypeName
+	driver.RowsColumnTypeNullable
+	driver.RowsColumnTypeScanType
+	driver.RowsColumnTypePrecisionScale
 }
 
 type rows struct {
@@ -53,6 +58,22 @@ func (r *rows) HasNextResultSet() bool {
 	return r.res.HasNextResultSet()
 }
 
+func (r *rows) ColumnTypeDatabaseTypeName(index int) string {
+	return x.ColumnTypeDatabaseTypeName(x.Column(r.res.CurrentResultSet(), index))
+}
+
+func (r *rows) ColumnTypeNullable(index int) (nullable, ok bool) {
+	return x.ColumnTypeNullable(x.Column(r.res.CurrentResultSet(), index))
+}
+
+func (r *rows) ColumnTypeScanType(index int) reflect.Type {
+	return x.ColumnTypeScanType(x.Column(r.res.CurrentResultSet(), index))
+}
+
+func (r *rows) ColumnTypePrecisionScale(index int) (precision, scale int64, ok bool) {
+	return x.ColumnTypePrecisionScale(x.Column(r.res.CurrentResultSet(), index))
+}
+
 func (r *rows) Next(dst []driver.Value) (err error) {
 	if !r.res.NextRow() {
 		return io.EOF
@@ -0,0 +1,39 @@
+package rows
+
+import (
+	"context"
+	"database/sql/driver"
+
+	"github.com/ydb-platform/ydb-go-sql/trace"
+)
+
+// WithTrace returns Rows which calls rows hooks of t with ctx. Optional
+// interfaces which r does not implement behave as database/sql defaults.
+func WithTrace(ctx context.Context, r driver.Rows, t trace.Trace) Rows {
+	return &traced{wrapped: wrapped{r}, ctx: ctx, t: t}
+}
+
+type traced struct {
+	wrapped
+
+	ctx context.Context
+	t   trace.Trace
+}
+
+func (r *traced) Next(dst []driver.Value) (err error) {
+	ctx := r.ctx
+	onDone := trace.TraceOnRowsNext(ctx, r.t, &ctx)
+	defer func() {
+		onDone(err)
+	}()
+	return r.Rows.Next(dst)
+}
+
+func (r *traced) Close() (err error) {
+	ctx := r.ctx
+	onDone := trace.TraceOnRowsClose(ctx, r.t, &ctx)
+	defer func() {
+		onDone(err)
+	}()
+	return r.Rows.Close()
+}
@@ -0,0 +1,155 @@
+package scan
+
+import (
+	"fmt"
+
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/types"
+
+	"github.com/ydb-platform/ydb-go-sql/internal/x"
+)
+
+// Variant is a value of YDB Variant type.
+type Variant struct {
+	// Name is a name of filled member for struct-based variant.
+	Name string
+	// Index is an index of filled member.
+	Index uint32
+	// Value is a value of filled member.
+	Value interface{}
+}
+
+// Any decodes value v of type t into Go value. Primitive values are decoded
+// as in x.Any, containers are decoded recursively as follows:
+//
+//	List<T>, Tuple<...>       []interface{}
+//	Struct<...>               map[string]interface{}
+//	Dict<K,V>                 map[interface{}]interface{}, String keys as string
+//	Variant<...>              Variant
+func Any(t *Ydb.Type, v *Ydb.Value) (interface{}, error) {
+	switch x := t.Type.(type) {
+	case *Ydb.Type_OptionalType:
+		item, ok := unwrap(x, v)
+		if !ok {
+			return nil, nil
+		}
+		return Any(x.OptionalType.Item, item)
+	case *Ydb.Type_VoidType:
+		return nil, nil
+	case *Ydb.Type_TypeId, *Ydb.Type_DecimalType:
+		return primitive(t, v)
+	case *Ydb.Type_ListType:
+		items := make([]interface{}, len(v.Items))
+		for i, item := range v.Items {
+			var err error
+			if items[i], err = Any(x.ListType.Item, item); err != nil {
+				return nil, err
+			}
+		}
+		return items, nil
+	case *Ydb.Type_TupleType:
+		if len(v.Items) != len(x.TupleType.Elements) {
+			return nil, errMalformed(t)
+		}
+		items := make([]interface{}, len(v.Items))
+		for i, item := range v.Items {
+			var err error
+			if items[i], err = Any(x.TupleType.Elements[i], item); err != nil {
+				return nil, err
+			}
+		}
+		return items, nil
+	case *Ydb.Type_StructType:
+		if len(v.Items) != len(x.StructType.Members) {
+			return nil, errMalformed(t)
+		}
+		m := make(map[string]interface{}, len(v.Items))
+		for i, member := range x.StructType.Members {
+			item, err := Any(member.Type, v.Items[i])
+			if err != nil {
+				return nil, err
+			}
+			m[member.Name] = item
+		}
+		return m, nil
+	case *Ydb.Type_DictType:
+		m := make(map[interface{}]interface{}, len(v.Pairs))
+		for _, pair := range v.Pairs {
+			key, err := Any(x.DictType.Key, pair.Key)
+			if err != nil {
+				return nil, err
+			}
+			if b, ok := key.([]byte); ok {
+				key = string(b)
+			}
+			if m[key], err = Any(x.DictType.Payload, pair.Payload); err != nil {
+				return nil, err
+			}
+		}
+		return m, nil
+	case *Ydb.Type_VariantType:
+		name, item, err := variantType(x, v.VariantIndex)
+		if err != nil {
+			return nil, err
+		}
+		value, err := Any(item, v.GetNestedValue())
+		if err != nil {
+			return nil, err
+		}
+		return Variant{
+			Name:  name,
+			Index: v.VariantIndex,
+			Value: value,
+		}, nil
+	default:
+		return nil, errUnsupported(t)
+	}
+}
+
+// unwrap returns value of Optional item or false if v is null.
+func unwrap(t *Ydb.Type_OptionalType, v *Ydb.Value) (*Ydb.Value, bool) {
+	if _, null := v.Value.(*Ydb.Value_NullFlagValue); null {
+		return nil, false
+	}
+	if _, optional := t.OptionalType.Item.Type.(*Ydb.Type_OptionalType); optional {
+		return v.GetNestedValue(), true
+	}
+	return v, true
+}
+
+// variantType returns name and type of index-th member of variant.
+func variantType(t *Ydb.Type_VariantType, index uint32) (name string, item *Ydb.Type, err error) {
+	switch x := t.VariantType.Type.(type) {
+	case *Ydb.VariantType_TupleItems:
+		if int(index) < len(x.TupleItems.Elements) {
+			return "", x.TupleItems.Elements[index], nil
+		}
+	case *Ydb.VariantType_StructItems:
+		if int(index) < len(x.StructItems.Members) {
+			m := x.StructItems.Members[index]
+			return m.Name, m.Type, nil
+		}
+	}
+	return "", nil, fmt.Errorf("ydb: variant index %d is out of range", index)
+}
+
+// primitive decodes value v of primitive or Decimal type t with x.Any.
+func primitive(t *Ydb.Type, v *Ydb.Value) (interface{}, error) {
+	r := &raw{t: t, v: v}
+	if r.Type() == types.TypeUnknown && !r.IsDecimal() {
+		return nil, fmt.Errorf("ydb: unsupported primitive type %s", t.GetTypeId().String())
+	}
+	value := x.Any(r)
+	if r.err != nil {
+		return nil, r.err
+	}
+	return value, nil
+}
+
+func errMalformed(t *Ydb.Type) error {
+	return fmt.Errorf("ydb: malformed value of type %s", x.TypeString(t))
+}
+
+func errUnsupported(t *Ydb.Type) error {
+	return fmt.Errorf("ydb: unsupported type %s", x.TypeString(t))
+}
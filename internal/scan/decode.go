@@ -0,0 +1,276 @@
+package scan
+
+import (
+	"database/sql"
+	"fmt"
+	"reflect"
+
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+
+	"github.com/ydb-platform/ydb-go-sql/internal/check"
+	"github.com/ydb-platform/ydb-go-sql/internal/x"
+)
+
+var typeScanner = reflect.TypeOf((*sql.Scanner)(nil)).Elem()
+
+// decode decodes value v of type t into dst which must be settable.
+func decode(t *Ydb.Type, v *Ydb.Value, dst reflect.Value) error {
+	if x, ok := t.Type.(*Ydb.Type_OptionalType); ok {
+		item, ok := unwrap(x, v)
+		if !ok {
+			return null(t, dst)
+		}
+		return decode(x.OptionalType.Item, item, dst)
+	}
+	if dst.Kind() == reflect.Ptr {
+		if dst.IsNil() {
+			dst.Set(reflect.New(dst.Type().Elem()))
+		}
+		return decode(t, v, dst.Elem())
+	}
+	if dst.CanAddr() && dst.Addr().Type().Implements(typeScanner) {
+		src, err := Any(t, v)
+		if err != nil {
+			return err
+		}
+		return dst.Addr().Interface().(sql.Scanner).Scan(src)
+	}
+	if dst.Kind() == reflect.Interface && dst.NumMethod() == 0 {
+		src, err := Any(t, v)
+		if err != nil {
+			return err
+		}
+		return assign(src, dst)
+	}
+	switch x := t.Type.(type) {
+	case *Ydb.Type_ListType:
+		return decodeItems(t, v.Items, func(int) *Ydb.Type { return x.ListType.Item }, dst)
+	case *Ydb.Type_TupleType:
+		if len(v.Items) != len(x.TupleType.Elements) {
+			return errMalformed(t)
+		}
+		return decodeItems(t, v.Items, func(i int) *Ydb.Type { return x.TupleType.Elements[i] }, dst)
+	case *Ydb.Type_StructType:
+		return decodeStruct(t, x.StructType, v, dst)
+	case *Ydb.Type_DictType:
+		return decodeDict(t, x.DictType, v, dst)
+	case *Ydb.Type_VariantType:
+		name, item, err := variantType(x, v.VariantIndex)
+		if err != nil {
+			return err
+		}
+		if dst.Type() != typeVariant {
+			return decode(item, v.GetNestedValue(), dst)
+		}
+		value, err := Any(item, v.GetNestedValue())
+		if err != nil {
+			return err
+		}
+		dst.Set(reflect.ValueOf(Variant{
+			Name:  name,
+			Index: v.VariantIndex,
+			Value: value,
+		}))
+		return nil
+	}
+	src, err := Any(t, v)
+	if err != nil {
+		return err
+	}
+	if err = assign(src, dst); err != nil {
+		return fmt.Errorf("%w: %s into %s", err, x.TypeString(t), dst.Type())
+	}
+	return nil
+}
+
+var typeVariant = reflect.TypeOf(Variant{})
+
+// isNull reports whether v is null of Optional type t.
+func isNull(t *Ydb.Type, v *Ydb.Value) bool {
+	x, ok := t.Type.(*Ydb.Type_OptionalType)
+	if !ok {
+		return false
+	}
+	_, ok = unwrap(x, v)
+	return !ok
+}
+
+// null decodes null of Optional type t into dst.
+func null(t *Ydb.Type, dst reflect.Value) error {
+	switch dst.Kind() {
+	case reflect.Ptr, reflect.Interface, reflect.Slice, reflect.Map:
+		dst.Set(reflect.Zero(dst.Type()))
+		return nil
+	}
+	if dst.CanAddr() && dst.Addr().Type().Implements(typeScanner) {
+		return dst.Addr().Interface().(sql.Scanner).Scan(nil)
+	}
+	return fmt.Errorf("ydb: cannot decode NULL of %s into %s", x.TypeString(t), dst.Type())
+}
+
+func decodeItems(t *Ydb.Type, items []*Ydb.Value, itemType func(int) *Ydb.Type, dst reflect.Value) error {
+	switch dst.Kind() {
+	case reflect.Slice:
+		dst.Set(reflect.MakeSlice(dst.Type(), len(items), len(items)))
+	case reflect.Array:
+		if dst.Len() != len(items) {
+			return fmt.Errorf("ydb: cannot decode %s of %d items into %s", x.TypeString(t), len(items), dst.Type())
+		}
+	default:
+		return errDecode(t, dst)
+	}
+	for i, item := range items {
+		if err := decode(itemType(i), item, dst.Index(i)); err != nil {
+			return fmt.Errorf("%w (item %d)", err, i)
+		}
+	}
+	return nil
+}
+
+func decodeStruct(t *Ydb.Type, st *Ydb.StructType, v *Ydb.Value, dst reflect.Value) error {
+	if len(v.Items) != len(st.Members) {
+		return errMalformed(t)
+	}
+	switch {
+	case dst.Kind() == reflect.Struct:
+		fields, err := check.Members(dst.Type())
+		if err != nil {
+			return err
+		}
+		for i, m := range st.Members {
+			f, ok := fields[m.Name]
+			if !ok {
+				continue
+			}
+			field := dst.FieldByIndex(f.Index)
+			if f.Nullable && isNull(m.Type, v.Items[i]) {
+				field.Set(reflect.Zero(field.Type()))
+				continue
+			}
+			if err := decode(m.Type, v.Items[i], field); err != nil {
+				return fmt.Errorf("%w (member %s)", err, m.Name)
+			}
+		}
+		return nil
+	case dst.Kind() == reflect.Map && dst.Type().Key().Kind() == reflect.String:
+		if dst.IsNil() {
+			dst.Set(reflect.MakeMapWithSize(dst.Type(), len(st.Members)))
+		}
+		for i, m := range st.Members {
+			item := reflect.New(dst.Type().Elem()).Elem()
+			if err := decode(m.Type, v.Items[i], item); err != nil {
+				return fmt.Errorf("%w (member %s)", err, m.Name)
+			}
+			dst.SetMapIndex(reflect.ValueOf(m.Name).Convert(dst.Type().Key()), item)
+		}
+		return nil
+	default:
+		return errDecode(t, dst)
+	}
+}
+
+func decodeDict(t *Ydb.Type, dt *Ydb.DictType, v *Ydb.Value, dst reflect.Value) error {
+	if dst.Kind() != reflect.Map {
+		return errDecode(t, dst)
+	}
+	dst.Set(reflect.MakeMapWithSize(dst.Type(), len(v.Pairs)))
+	for _, pair := range v.Pairs {
+		key := reflect.New(dst.Type().Key()).Elem()
+		if err := decode(dt.Key, pair.Key, key); err != nil {
+			return fmt.Errorf("%w (dict key)", err)
+		}
+		payload := reflect.New(dst.Type().Elem()).Elem()
+		if err := decode(dt.Payload, pair.Payload, payload); err != nil {
+			return fmt.Errorf("%w (dict payload of key %v)", err, key)
+		}
+		dst.SetMapIndex(key, payload)
+	}
+	return nil
+}
+
+// assign assigns primitive value src into dst converting it if needed.
+func assign(src interface{}, dst reflect.Value) error {
+	if src == nil {
+		dst.Set(reflect.Zero(dst.Type()))
+		return nil
+	}
+	sv := reflect.ValueOf(src)
+	if sv.Type().AssignableTo(dst.Type()) {
+		dst.Set(sv)
+		return nil
+	}
+	switch dst.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		var n int64
+		switch sv.Kind() {
+		case reflect.Int64:
+			n = sv.Int()
+		case reflect.Uint64:
+			if sv.Uint() > 1<<63-1 {
+				return errOverflow
+			}
+			n = int64(sv.Uint())
+		default:
+			return errConvert
+		}
+		if dst.OverflowInt(n) {
+			return errOverflow
+		}
+		dst.SetInt(n)
+		return nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		var n uint64
+		switch sv.Kind() {
+		case reflect.Int64:
+			if sv.Int() < 0 {
+				return errOverflow
+			}
+			n = uint64(sv.Int())
+		case reflect.Uint64:
+			n = sv.Uint()
+		default:
+			return errConvert
+		}
+		if dst.OverflowUint(n) {
+			return errOverflow
+		}
+		dst.SetUint(n)
+		return nil
+	case reflect.Float32, reflect.Float64:
+		if sv.Kind() != reflect.Float64 {
+			return errConvert
+		}
+		dst.SetFloat(sv.Float())
+		return nil
+	case reflect.String:
+		switch sv.Kind() {
+		case reflect.String:
+			dst.SetString(sv.String())
+			return nil
+		case reflect.Slice:
+			if sv.Type().Elem().Kind() == reflect.Uint8 {
+				dst.SetString(string(sv.Bytes()))
+				return nil
+			}
+		}
+	case reflect.Slice:
+		if dst.Type().Elem().Kind() == reflect.Uint8 && sv.Kind() == reflect.String {
+			dst.SetBytes([]byte(sv.String()))
+			return nil
+		}
+	}
+	if sv.Type().ConvertibleTo(dst.Type()) && sv.Kind() == dst.Kind() {
+		dst.Set(sv.Convert(dst.Type()))
+		return nil
+	}
+	return errConvert
+}
+
+var (
+	errConvert  = fmt.Errorf("ydb: cannot decode")
+	errOverflow = fmt.Errorf("ydb: value overflows destination")
+)
+
+func errDecode(t *Ydb.Type, dst reflect.Value) error {
+	return fmt.Errorf("%w: %s into %s", errConvert, x.TypeString(t), dst.Type())
+}
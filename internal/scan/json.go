@@ -0,0 +1,127 @@
+package scan
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/types"
+
+	"github.com/ydb-platform/ydb-go-sql/internal/yson"
+)
+
+// JSON wraps Go value for passing it as Json or JsonDocument query parameter
+// and for scanning Json or JsonDocument column into it with encoding/json
+// package.
+type JSON struct {
+	// V is a value which is marshaled to query parameter or pointer to value
+	// which column is unmarshaled into.
+	V interface{}
+
+	// Document makes query parameter of JsonDocument type instead of Json.
+	Document bool
+}
+
+// Value implements driver.Valuer interface. Value is always passed as
+// Optional<Json> (Optional<JsonDocument>), so the same parameter declaration
+// fits both values and NULL which nil V and nil pointer are passed as.
+func (j *JSON) Value() (driver.Value, error) {
+	t := types.TypeJSON
+	if j.Document {
+		t = types.TypeJSONDocument
+	}
+	if isNil(j.V) {
+		return types.NullValue(t), nil
+	}
+	b, err := json.Marshal(j.V)
+	if err != nil {
+		return nil, fmt.Errorf("ydb: marshal json: %w", err)
+	}
+	if j.Document {
+		return types.OptionalValue(types.JSONDocumentValueFromBytes(b)), nil
+	}
+	return types.OptionalValue(types.JSONValueFromBytes(b)), nil
+}
+
+// Scan implements sql.Scanner interface. NULL is unmarshaled as JSON null.
+func (j *JSON) Scan(src interface{}) error {
+	b, err := bytesOf(src, "json")
+	if err != nil {
+		return err
+	}
+	if b == nil {
+		b = []byte("null")
+	}
+	if err := json.Unmarshal(b, j.V); err != nil {
+		return fmt.Errorf("ydb: unmarshal json: %w", err)
+	}
+	return nil
+}
+
+// YSON wraps Go value for passing it as Yson query parameter and for scanning
+// Yson column into it. Values are converted between YSON and JSON and
+// marshaled with encoding/json package, so json tags of struct fields are
+// used. Attributes of YSON nodes are dropped on scanning.
+type YSON struct {
+	// V is a value which is marshaled to query parameter or pointer to value
+	// which column is unmarshaled into.
+	V interface{}
+}
+
+// Value implements driver.Valuer interface. Value is always passed as
+// Optional<Yson>, so the same parameter declaration fits both values and NULL
+// which nil V and nil pointer are passed as.
+func (y *YSON) Value() (driver.Value, error) {
+	if isNil(y.V) {
+		return types.NullValue(types.TypeYSON), nil
+	}
+	b, err := json.Marshal(y.V)
+	if err != nil {
+		return nil, fmt.Errorf("ydb: marshal yson: %w", err)
+	}
+	b, err = yson.FromJSON(b)
+	if err != nil {
+		return nil, err
+	}
+	return types.OptionalValue(types.YSONValueFromBytes(b)), nil
+}
+
+// Scan implements sql.Scanner interface. NULL is unmarshaled as JSON null.
+func (y *YSON) Scan(src interface{}) error {
+	b, err := bytesOf(src, "yson")
+	if err != nil {
+		return err
+	}
+	if b == nil {
+		b = []byte("null")
+	} else if b, err = yson.ToJSON(b); err != nil {
+		return err
+	}
+	if err := json.Unmarshal(b, y.V); err != nil {
+		return fmt.Errorf("ydb: unmarshal yson: %w", err)
+	}
+	return nil
+}
+
+// bytesOf returns content of column value src or nil if src is NULL.
+func bytesOf(src interface{}, format string) ([]byte, error) {
+	switch x := src.(type) {
+	case []byte:
+		return x, nil
+	case string:
+		return []byte(x), nil
+	case nil:
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("ydb: cannot scan %T as %s", src, format)
+	}
+}
+
+func isNil(v interface{}) bool {
+	if v == nil {
+		return true
+	}
+	rv := reflect.ValueOf(v)
+	return rv.Kind() == reflect.Ptr && rv.IsNil()
+}
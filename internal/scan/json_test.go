@@ -0,0 +1,90 @@
+package scan
+
+import (
+	"database/sql/driver"
+	"reflect"
+	"testing"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/types"
+)
+
+type info struct {
+	Genre  string   `json:"genre"`
+	Rating *float64 `json:"rating"`
+}
+
+func TestValue(t *testing.T) {
+	for _, test := range []struct {
+		name string
+		v    driver.Valuer
+		exp  driver.Value
+		err  bool
+	}{
+		{name: "json", v: &JSON{V: info{Genre: "comedy"}}, exp: types.OptionalValue(types.JSONValue(`{"genre":"comedy","rating":null}`))},
+		{name: "json document", v: &JSON{V: []int{1}, Document: true}, exp: types.OptionalValue(types.JSONDocumentValue(`[1]`))},
+		{name: "json nil", v: &JSON{}, exp: types.NullValue(types.TypeJSON)},
+		{name: "json nil pointer", v: &JSON{V: (*info)(nil), Document: true}, exp: types.NullValue(types.TypeJSONDocument)},
+		{name: "json unsupported", v: &JSON{V: make(chan int)}, err: true},
+		{name: "yson", v: &YSON{V: info{Genre: "comedy"}}, exp: types.OptionalValue(types.YSONValue(`{"genre"="comedy";"rating"=#;}`))},
+		{name: "yson nil", v: &YSON{}, exp: types.NullValue(types.TypeYSON)},
+		{name: "yson unsupported", v: &YSON{V: make(chan int)}, err: true},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			act, err := test.v.Value()
+			if test.err {
+				if err == nil {
+					t.Fatalf("expected error, got %v", act)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(act, test.exp) {
+				t.Fatalf("unexpected value: %v; want %v", act, test.exp)
+			}
+		})
+	}
+}
+
+func TestScanJSON(t *testing.T) {
+	rating := 8.5
+	for _, test := range []struct {
+		name string
+		yson bool
+		src  interface{}
+		exp  *info
+		err  bool
+	}{
+		{name: "json bytes", src: []byte(`{"genre":"comedy","rating":8.5}`), exp: &info{Genre: "comedy", Rating: &rating}},
+		{name: "json string", src: `{"genre":"comedy"}`, exp: &info{Genre: "comedy"}},
+		{name: "json null", src: nil, exp: nil},
+		{name: "json invalid", src: []byte(`{genre=comedy}`), err: true},
+		{name: "json unsupported", src: int64(1), err: true},
+		{name: "yson text", yson: true, src: []byte(`{genre=comedy;rating=8.5}`), exp: &info{Genre: "comedy", Rating: &rating}},
+		{name: "yson binary", yson: true, src: []byte("{\x01\x0agenre=\x01\x0ccomedy}"), exp: &info{Genre: "comedy"}},
+		{name: "yson null", yson: true, src: nil, exp: nil},
+		{name: "yson invalid", yson: true, src: []byte(`{"genre":"comedy"}`), err: true},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			act := &info{Genre: "drama"}
+			var s interface{ Scan(interface{}) error } = &JSON{V: &act}
+			if test.yson {
+				s = &YSON{V: &act}
+			}
+			err := s.Scan(test.src)
+			if test.err {
+				if err == nil {
+					t.Fatalf("expected error, got %+v", act)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(act, test.exp) {
+				t.Fatalf("unexpected value: %+v; want %+v", act, test.exp)
+			}
+		})
+	}
+}
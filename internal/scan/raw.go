@@ -0,0 +1,136 @@
+package scan
+
+import (
+	"encoding/binary"
+	"time"
+
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/types"
+)
+
+const (
+	tzLayoutDate      = "2006-01-02,MST"
+	tzLayoutDatetime  = "2006-01-02T15:04:05,MST"
+	tzLayoutTimestamp = "2006-01-02T15:04:05.000000,MST"
+)
+
+// primitiveTypes maps identifiers of primitive types to types.
+var primitiveTypes = map[Ydb.Type_PrimitiveTypeId]types.Type{
+	Ydb.Type_BOOL:          types.TypeBool,
+	Ydb.Type_INT8:          types.TypeInt8,
+	Ydb.Type_UINT8:         types.TypeUint8,
+	Ydb.Type_INT16:         types.TypeInt16,
+	Ydb.Type_UINT16:        types.TypeUint16,
+	Ydb.Type_INT32:         types.TypeInt32,
+	Ydb.Type_UINT32:        types.TypeUint32,
+	Ydb.Type_INT64:         types.TypeInt64,
+	Ydb.Type_UINT64:        types.TypeUint64,
+	Ydb.Type_FLOAT:         types.TypeFloat,
+	Ydb.Type_DOUBLE:        types.TypeDouble,
+	Ydb.Type_DATE:          types.TypeDate,
+	Ydb.Type_DATETIME:      types.TypeDatetime,
+	Ydb.Type_TIMESTAMP:     types.TypeTimestamp,
+	Ydb.Type_INTERVAL:      types.TypeInterval,
+	Ydb.Type_TZ_DATE:       types.TypeTzDate,
+	Ydb.Type_TZ_DATETIME:   types.TypeTzDatetime,
+	Ydb.Type_TZ_TIMESTAMP:  types.TypeTzTimestamp,
+	Ydb.Type_STRING:        types.TypeString,
+	Ydb.Type_UTF8:          types.TypeUTF8,
+	Ydb.Type_YSON:          types.TypeYSON,
+	Ydb.Type_JSON:          types.TypeJSON,
+	Ydb.Type_UUID:          types.TypeUUID,
+	Ydb.Type_JSON_DOCUMENT: types.TypeJSONDocument,
+	Ydb.Type_DYNUMBER:      types.TypeDyNumber,
+}
+
+// raw adapts value v of primitive or Decimal type t to types.RawValue, so
+// nested values are converted to Go values by x.Any the same way as values
+// of result columns. Only methods called by x.Any for such types are
+// implemented.
+type raw struct {
+	types.RawValue
+
+	t   *Ydb.Type
+	v   *Ydb.Value
+	err error
+}
+
+func (r *raw) Type() types.Type {
+	if t, ok := primitiveTypes[r.t.GetTypeId()]; ok {
+		return t
+	}
+	return types.TypeUnknown
+}
+
+func (r *raw) IsOptional() bool { return false }
+func (r *raw) IsNull() bool     { return false }
+func (r *raw) Err() error       { return r.err }
+
+func (r *raw) IsDecimal() bool {
+	_, ok := r.t.Type.(*Ydb.Type_DecimalType)
+	return ok
+}
+
+func (r *raw) UnwrapDecimal() (d types.Decimal) {
+	t := r.t.GetDecimalType()
+	binary.BigEndian.PutUint64(d.Bytes[:8], r.v.GetHigh_128())
+	binary.BigEndian.PutUint64(d.Bytes[8:], r.v.GetLow_128())
+	d.Precision, d.Scale = t.GetPrecision(), t.GetScale()
+	return d
+}
+
+func (r *raw) Bool() bool       { return r.v.GetBoolValue() }
+func (r *raw) Int8() int8       { return int8(r.v.GetInt32Value()) }
+func (r *raw) Uint8() uint8     { return uint8(r.v.GetUint32Value()) }
+func (r *raw) Int16() int16     { return int16(r.v.GetInt32Value()) }
+func (r *raw) Uint16() uint16   { return uint16(r.v.GetUint32Value()) }
+func (r *raw) Int32() int32     { return r.v.GetInt32Value() }
+func (r *raw) Uint32() uint32   { return r.v.GetUint32Value() }
+func (r *raw) Int64() int64     { return r.v.GetInt64Value() }
+func (r *raw) Uint64() uint64   { return r.v.GetUint64Value() }
+func (r *raw) Float() float32   { return r.v.GetFloatValue() }
+func (r *raw) Double() float64  { return r.v.GetDoubleValue() }
+func (r *raw) String() []byte   { return r.v.GetBytesValue() }
+func (r *raw) UTF8() string     { return r.v.GetTextValue() }
+func (r *raw) YSON() []byte     { return []byte(r.v.GetTextValue()) }
+func (r *raw) JSON() []byte     { return []byte(r.v.GetTextValue()) }
+func (r *raw) DyNumber() string { return r.v.GetTextValue() }
+
+func (r *raw) JSONDocument() []byte {
+	return []byte(r.v.GetTextValue())
+}
+
+func (r *raw) Date() time.Time {
+	return time.Unix(int64(r.v.GetUint32Value())*24*60*60, 0)
+}
+
+func (r *raw) Datetime() time.Time {
+	return time.Unix(int64(r.v.GetUint32Value()), 0)
+}
+
+func (r *raw) Timestamp() time.Time {
+	us := r.v.GetUint64Value()
+	return time.Unix(int64(us/1e6), int64(us%1e6)*1000)
+}
+
+func (r *raw) Interval() time.Duration {
+	return time.Duration(r.v.GetInt64Value())
+}
+
+func (r *raw) TzDate() time.Time      { return r.tz(tzLayoutDate) }
+func (r *raw) TzDatetime() time.Time  { return r.tz(tzLayoutDatetime) }
+func (r *raw) TzTimestamp() time.Time { return r.tz(tzLayoutTimestamp) }
+
+func (r *raw) tz(layout string) time.Time {
+	t, err := time.Parse(layout, r.v.GetTextValue())
+	if err != nil && r.err == nil {
+		r.err = err
+	}
+	return t
+}
+
+func (r *raw) UUID() (uuid [16]byte) {
+	binary.BigEndian.PutUint64(uuid[:8], r.v.GetHigh_128())
+	binary.BigEndian.PutUint64(uuid[8:], r.v.GetLow_128())
+	return uuid
+}
@@ -0,0 +1,103 @@
+package scan
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/types"
+
+	"github.com/ydb-platform/ydb-go-sql/internal/x"
+)
+
+// Kind is a kind of YDB container type.
+type Kind int
+
+const (
+	KindList Kind = iota
+	KindTuple
+	KindStruct
+	KindDict
+	KindVariant
+)
+
+func (k Kind) String() string {
+	switch k {
+	case KindList:
+		return "List"
+	case KindTuple:
+		return "Tuple"
+	case KindStruct:
+		return "Struct"
+	case KindDict:
+		return "Dict"
+	case KindVariant:
+		return "Variant"
+	default:
+		return fmt.Sprintf("Kind(%d)", int(k))
+	}
+}
+
+func (k Kind) match(t *Ydb.Type) bool {
+	switch t.Type.(type) {
+	case *Ydb.Type_ListType:
+		return k == KindList
+	case *Ydb.Type_TupleType:
+		return k == KindTuple
+	case *Ydb.Type_StructType:
+		return k == KindStruct
+	case *Ydb.Type_DictType:
+		return k == KindDict
+	case *Ydb.Type_VariantType:
+		return k == KindVariant
+	default:
+		return false
+	}
+}
+
+// Scanner is a sql.Scanner which decodes YDB container of kind into Go value
+// pointed by dst.
+type Scanner struct {
+	kind Kind
+	dst  interface{}
+}
+
+func New(kind Kind, dst interface{}) *Scanner {
+	return &Scanner{
+		kind: kind,
+		dst:  dst,
+	}
+}
+
+// Scan implements sql.Scanner interface. NULL is decoded as zero value.
+func (s *Scanner) Scan(src interface{}) error {
+	dst := reflect.ValueOf(s.dst)
+	if dst.Kind() != reflect.Ptr || dst.IsNil() {
+		return fmt.Errorf("ydb: scan destination must be non-nil pointer, got %T", s.dst)
+	}
+	switch src := src.(type) {
+	case nil:
+		dst.Elem().Set(reflect.Zero(dst.Elem().Type()))
+		return nil
+	case types.Value:
+		tv := src.ToYDB()
+		t, v := tv.Type, tv.Value
+		for {
+			opt, ok := t.Type.(*Ydb.Type_OptionalType)
+			if !ok {
+				break
+			}
+			if v, ok = unwrap(opt, v); !ok {
+				dst.Elem().Set(reflect.Zero(dst.Elem().Type()))
+				return nil
+			}
+			t = opt.OptionalType.Item
+		}
+		if !s.kind.match(t) {
+			return fmt.Errorf("ydb: cannot scan %s as %s", x.TypeString(t), s.kind)
+		}
+		return decode(t, v, dst.Elem())
+	default:
+		return fmt.Errorf("ydb: cannot scan %T as %s", src, s.kind)
+	}
+}
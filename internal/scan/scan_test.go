@@ -0,0 +1,220 @@
+package scan
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/types"
+)
+
+type episode struct {
+	ID      uint64  `ydb:"episode_id"`
+	Title   string  `ydb:"title"`
+	Comment *string `ydb:"comment"`
+	Skipped string  `ydb:"-"`
+}
+
+type series struct {
+	ID    uint64 `ydb:"series_id"`
+	Title string `ydb:"title,nullable"`
+}
+
+type badTag struct {
+	ID uint64 `ydb:"id,unknown"`
+}
+
+func TestScan(t *testing.T) {
+	comment := "pilot"
+	episodeValue := func(id uint64, title string, comment types.Value) types.Value {
+		return types.StructValue(
+			types.StructFieldValue("episode_id", types.Uint64Value(id)),
+			types.StructFieldValue("title", types.UTF8Value(title)),
+			types.StructFieldValue("comment", comment),
+			types.StructFieldValue("air_date", types.DateValue(1)),
+		)
+	}
+	for _, test := range []struct {
+		name string
+		kind Kind
+		src  interface{}
+		dst  interface{}
+		exp  interface{}
+		err  bool
+	}{
+		{
+			name: "list",
+			kind: KindList,
+			src:  types.ListValue(types.Uint64Value(1), types.Uint64Value(2)),
+			dst:  new([]uint64),
+			exp:  []uint64{1, 2},
+		},
+		{
+			name: "list into array",
+			kind: KindList,
+			src:  types.ListValue(types.Int32Value(1), types.Int32Value(2)),
+			dst:  new([2]int),
+			exp:  [2]int{1, 2},
+		},
+		{
+			name: "optional list",
+			kind: KindList,
+			src:  types.OptionalValue(types.ListValue(types.UTF8Value("foo"))),
+			dst:  new([]string),
+			exp:  []string{"foo"},
+		},
+		{
+			name: "null list",
+			kind: KindList,
+			src:  types.NullValue(types.List(types.TypeUTF8)),
+			dst:  &[]string{"foo"},
+			exp:  []string(nil),
+		},
+		{
+			name: "nil",
+			kind: KindList,
+			src:  nil,
+			dst:  &[]string{"foo"},
+			exp:  []string(nil),
+		},
+		{
+			name: "list of optionals",
+			kind: KindList,
+			src: types.ListValue(
+				types.OptionalValue(types.Int64Value(1)),
+				types.NullValue(types.TypeInt64),
+			),
+			dst: new([]*int64),
+			exp: []*int64{func() *int64 { v := int64(1); return &v }(), nil},
+		},
+		{
+			name: "list of structs",
+			kind: KindList,
+			src: types.ListValue(
+				episodeValue(1, "Yesterday's Jam", types.OptionalValue(types.UTF8Value(comment))),
+				episodeValue(2, "Calamity Jen", types.NullValue(types.TypeUTF8)),
+			),
+			dst: new([]episode),
+			exp: []episode{
+				{ID: 1, Title: "Yesterday's Jam", Comment: &comment},
+				{ID: 2, Title: "Calamity Jen"},
+			},
+		},
+		{
+			name: "nullable member",
+			kind: KindList,
+			src: types.ListValue(
+				types.StructValue(
+					types.StructFieldValue("series_id", types.Uint64Value(1)),
+					types.StructFieldValue("title", types.NullValue(types.TypeUTF8)),
+				),
+			),
+			dst: &[]series{{Title: "IT Crowd"}},
+			exp: []series{{ID: 1}},
+		},
+		{
+			name: "unknown tag option",
+			kind: KindStruct,
+			src:  types.StructValue(types.StructFieldValue("id", types.Uint64Value(1))),
+			dst:  new(badTag),
+			err:  true,
+		},
+		{
+			name: "list of dates",
+			kind: KindList,
+			src:  types.ListValue(types.DateValue(1)),
+			dst:  new([]time.Time),
+			exp:  []time.Time{time.Date(1970, 1, 2, 0, 0, 0, 0, time.UTC)},
+		},
+		{
+			name: "struct into map",
+			kind: KindStruct,
+			src: types.StructValue(
+				types.StructFieldValue("a", types.Int32Value(1)),
+				types.StructFieldValue("b", types.Int32Value(2)),
+			),
+			dst: new(map[string]int),
+			exp: map[string]int{"a": 1, "b": 2},
+		},
+		{
+			name: "tuple",
+			kind: KindTuple,
+			src:  types.TupleValue(types.UTF8Value("foo"), types.Uint32Value(42), types.ListValue(types.BoolValue(true))),
+			dst:  new([]interface{}),
+			exp:  []interface{}{"foo", int64(42), []interface{}{true}},
+		},
+		{
+			name: "dict",
+			kind: KindDict,
+			src: types.DictValue(
+				types.StringValueFromString("foo"), types.ListValue(types.Uint8Value(1)),
+				types.StringValueFromString("bar"), types.ListValue(types.Uint8Value(2), types.Uint8Value(3)),
+			),
+			dst: new(map[string][]uint8),
+			exp: map[string][]uint8{"foo": {1}, "bar": {2, 3}},
+		},
+		{
+			name: "variant",
+			kind: KindVariant,
+			src: types.VariantValue(types.UTF8Value("foo"), 1, types.Variant(types.Struct(
+				types.StructField("a", types.TypeInt32),
+				types.StructField("b", types.TypeUTF8),
+			))),
+			dst: new(Variant),
+			exp: Variant{Name: "b", Index: 1, Value: "foo"},
+		},
+		{
+			name: "variant member",
+			kind: KindVariant,
+			src: types.VariantValue(types.UTF8Value("foo"), 1, types.Variant(types.Tuple(
+				types.TypeInt32, types.TypeUTF8,
+			))),
+			dst: new(string),
+			exp: "foo",
+		},
+		{
+			name: "kind mismatch",
+			kind: KindDict,
+			src:  types.ListValue(types.Uint64Value(1)),
+			dst:  new(map[string]string),
+			err:  true,
+		},
+		{
+			name: "overflow",
+			kind: KindList,
+			src:  types.ListValue(types.Int32Value(1000)),
+			dst:  new([]int8),
+			err:  true,
+		},
+		{
+			name: "null into non-nullable",
+			kind: KindList,
+			src:  types.ListValue(types.NullValue(types.TypeInt64)),
+			dst:  new([]int64),
+			err:  true,
+		},
+		{
+			name: "non-pointer destination",
+			kind: KindList,
+			src:  types.ListValue(types.Int64Value(1)),
+			dst:  []int64{},
+			err:  true,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			err := New(test.kind, test.dst).Scan(test.src)
+			if test.err {
+				if err == nil {
+					t.Fatalf("expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if act := reflect.ValueOf(test.dst).Elem().Interface(); !reflect.DeepEqual(act, test.exp) {
+				t.Fatalf("unexpected value: %#v; want %#v", act, test.exp)
+			}
+		})
+	}
+}
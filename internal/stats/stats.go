@@ -0,0 +1,112 @@
+package stats
+
+import (
+	"time"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/stats"
+)
+
+type (
+	CompilationStats = stats.CompilationStats
+	TableAccess      = stats.TableAccess
+	OperationStats   = stats.OperationStats
+)
+
+// QueryStats is a statistics of query execution.
+type QueryStats struct {
+	// ProcessCPUTime is a CPU time spent by server on processing of query
+	// besides compilation and phases.
+	ProcessCPUTime time.Duration
+
+	// Compilation is a statistics of query compilation or nil if server did
+	// not report it.
+	Compilation *CompilationStats
+
+	// Phases are statistics of query execution phases.
+	Phases []QueryPhase
+}
+
+// QueryPhase is a statistics of query execution phase.
+type QueryPhase struct {
+	Duration       time.Duration
+	CPUTime        time.Duration
+	AffectedShards uint64
+
+	// Tables are statistics of access to tables within phase.
+	Tables []TableAccess
+}
+
+// New returns QueryStats from statistics of ydb-go-sdk query result.
+func New(s stats.QueryStats) QueryStats {
+	q := QueryStats{
+		ProcessCPUTime: s.ProcessCPUTime(),
+		Compilation:    s.Compilation(),
+	}
+	for {
+		p, ok := s.NextPhase()
+		if !ok {
+			break
+		}
+		phase := QueryPhase{
+			Duration:       p.Duration(),
+			CPUTime:        p.CPUTime(),
+			AffectedShards: p.AffectedShards(),
+		}
+		for {
+			t, ok := p.NextTableAccess()
+			if !ok {
+				break
+			}
+			phase.Tables = append(phase.Tables, *t)
+		}
+		q.Phases = append(q.Phases, phase)
+	}
+	return q
+}
+
+// CPUTime returns total CPU time of query: processing, compilation and
+// phases.
+func (s QueryStats) CPUTime() time.Duration {
+	d := s.ProcessCPUTime
+	if s.Compilation != nil {
+		d += s.Compilation.CPUTime
+	}
+	for _, p := range s.Phases {
+		d += p.CPUTime
+	}
+	return d
+}
+
+// Duration returns total duration of query execution phases.
+func (s QueryStats) Duration() time.Duration {
+	var d time.Duration
+	for _, p := range s.Phases {
+		d += p.Duration
+	}
+	return d
+}
+
+// Reads, Updates and Deletes return total number of rows and bytes read,
+// updated and deleted by query over all phases and tables.
+func (s QueryStats) Reads() OperationStats {
+	return s.sum(func(t TableAccess) OperationStats { return t.Reads })
+}
+
+func (s QueryStats) Updates() OperationStats {
+	return s.sum(func(t TableAccess) OperationStats { return t.Updates })
+}
+
+func (s QueryStats) Deletes() OperationStats {
+	return s.sum(func(t TableAccess) OperationStats { return t.Deletes })
+}
+
+func (s QueryStats) sum(op func(TableAccess) OperationStats) (total OperationStats) {
+	for _, p := range s.Phases {
+		for _, t := range p.Tables {
+			o := op(t)
+			total.Rows += o.Rows
+			total.Bytes += o.Bytes
+		}
+	}
+	return total
+}
@@ -0,0 +1,78 @@
+package stats
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/stats"
+)
+
+type queryStats struct {
+	phases []*queryPhase
+}
+
+func (s *queryStats) ProcessCPUTime() time.Duration { return time.Millisecond }
+
+func (s *queryStats) Compilation() *stats.CompilationStats {
+	return &stats.CompilationStats{CPUTime: 2 * time.Millisecond}
+}
+
+func (s *queryStats) NextPhase() (stats.QueryPhase, bool) {
+	if len(s.phases) == 0 {
+		return nil, false
+	}
+	p := s.phases[0]
+	s.phases = s.phases[1:]
+	return p, true
+}
+
+type queryPhase struct {
+	tables []stats.TableAccess
+}
+
+func (p *queryPhase) NextTableAccess() (*stats.TableAccess, bool) {
+	if len(p.tables) == 0 {
+		return nil, false
+	}
+	t := p.tables[0]
+	p.tables = p.tables[1:]
+	return &t, true
+}
+
+func (p *queryPhase) Duration() time.Duration { return 10 * time.Millisecond }
+func (p *queryPhase) CPUTime() time.Duration  { return 3 * time.Millisecond }
+func (p *queryPhase) AffectedShards() uint64  { return 1 }
+
+func TestNew(t *testing.T) {
+	s := New(&queryStats{phases: []*queryPhase{
+		{tables: []stats.TableAccess{
+			{Name: "a", Reads: stats.OperationStats{Rows: 1, Bytes: 10}},
+			{Name: "b", Reads: stats.OperationStats{Rows: 2, Bytes: 20}},
+		}},
+		{tables: []stats.TableAccess{
+			{Name: "a", Updates: stats.OperationStats{Rows: 3, Bytes: 30}},
+		}},
+	}})
+	if len(s.Phases) != 2 || len(s.Phases[0].Tables) != 2 || len(s.Phases[1].Tables) != 1 {
+		t.Fatalf("unexpected phases: %+v", s.Phases)
+	}
+	if s.Phases[0].Tables[1].Name != "b" {
+		t.Fatalf("unexpected table: %+v", s.Phases[0].Tables[1])
+	}
+	for _, test := range []struct {
+		name string
+		act  interface{}
+		exp  interface{}
+	}{
+		{"reads", s.Reads(), OperationStats{Rows: 3, Bytes: 30}},
+		{"updates", s.Updates(), OperationStats{Rows: 3, Bytes: 30}},
+		{"deletes", s.Deletes(), OperationStats{}},
+		{"cpu time", s.CPUTime(), 9 * time.Millisecond},
+		{"duration", s.Duration(), 20 * time.Millisecond},
+	} {
+		if !reflect.DeepEqual(test.act, test.exp) {
+			t.Errorf("unexpected %s: %v; want %v", test.name, test.act, test.exp)
+		}
+	}
+}
@@ -6,13 +6,18 @@ import (
 	"fmt"
 
 	"github.com/ydb-platform/ydb-go-sdk/v3/table"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/options"
 
 	"github.com/ydb-platform/ydb-go-sql/internal/check"
 	"github.com/ydb-platform/ydb-go-sql/internal/errors"
 	"github.com/ydb-platform/ydb-go-sql/internal/mode"
 	"github.com/ydb-platform/ydb-go-sql/internal/nop"
+	"github.com/ydb-platform/ydb-go-sql/internal/query"
+	"github.com/ydb-platform/ydb-go-sql/internal/querylog"
 	"github.com/ydb-platform/ydb-go-sql/internal/rows"
+	"github.com/ydb-platform/ydb-go-sql/internal/tx"
 	"github.com/ydb-platform/ydb-go-sql/internal/x"
+	"github.com/ydb-platform/ydb-go-sql/trace"
 )
 
 type Stmt interface {
@@ -26,28 +31,89 @@ type Stmt interface {
 type stmt struct {
 	stmt             table.Statement
 	defaultTxControl *table.TransactionControl
+	dataOpts         []options.ExecuteDataQueryOption
+	query            query.Querier
+	trace            trace.Trace
+	log              *querylog.Log
+	explain          querylog.Explain
+
+	// tx returns transaction which is active on connection of statement.
+	// Statement executes within this transaction if it is not nil, so
+	// statement prepared outside of transaction could be reused within it.
+	tx func() tx.Tx
 }
 
-func (s *stmt) QueryContext(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
+func (s *stmt) QueryContext(ctx context.Context, args []driver.NamedValue) (_ driver.Rows, err error) {
+	a, err := s.query.Bind(ctx, s.stmt.Text(), args)
+	if err == nil {
+		args = a
+	}
+	onDone := trace.TraceOnQuery(ctx, s.trace, &ctx, s.stmt.Text(), x.QueryMode(ctx).String(), len(args), s.txID(), true)
+	onLog := s.log.Start(ctx, s.entry(ctx, "ydb: query", args))
+	defer func() {
+		onDone(err)
+		if err != nil {
+			onLog(0, err)
+		}
+	}()
+	if err != nil {
+		return nil, err
+	}
+	r, err := s.queryContext(ctx, args)
+	if r == nil {
+		return nil, err
+	}
+	if s.log != nil {
+		r = rows.WithCount(r, onLog)
+	}
+	return rows.WithTrace(ctx, r, s.trace), err
+}
+
+func (s *stmt) queryContext(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
+	if t := s.tx(); t != nil {
+		return t.StmtQueryContext(ctx, s.stmt, args)
+	}
 	switch m := x.QueryMode(ctx); m {
 	case mode.DataQuery:
-		_, res, err := s.stmt.Execute(ctx, x.TxControl(ctx, s.defaultTxControl), x.ToQueryParams(args), x.DataQueryOptions(ctx)...)
+		_, res, err := s.stmt.Execute(ctx, x.TxControl(ctx, s.defaultTxControl), x.ToQueryParams(args), x.DataQueryOptions(ctx, s.dataOpts)...)
 		if err != nil {
 			return nil, errors.Map(err)
 		}
+		x.ReportStats(ctx, res.Stats())
 		return rows.Result(res), errors.Map(res.Err())
 	default:
 		return nil, fmt.Errorf("unsupported query mode %s type for execute statement query", m)
 	}
 }
 
-func (s *stmt) ExecContext(ctx context.Context, args []driver.NamedValue) (driver.Result, error) {
+func (s *stmt) ExecContext(ctx context.Context, args []driver.NamedValue) (_ driver.Result, err error) {
+	a, err := s.query.Bind(ctx, s.stmt.Text(), args)
+	if err == nil {
+		args = a
+	}
+	onDone := trace.TraceOnExec(ctx, s.trace, &ctx, s.stmt.Text(), x.QueryMode(ctx).String(), len(args), s.txID(), true)
+	onLog := s.log.Start(ctx, s.entry(ctx, "ydb: exec", args))
+	defer func() {
+		onDone(err)
+		onLog(-1, err)
+	}()
+	if err != nil {
+		return nil, err
+	}
+	return s.exec(ctx, args)
+}
+
+func (s *stmt) exec(ctx context.Context, args []driver.NamedValue) (driver.Result, error) {
+	if t := s.tx(); t != nil {
+		return t.StmtExecContext(ctx, s.stmt, args)
+	}
 	switch m := x.QueryMode(ctx); m {
 	case mode.DataQuery:
-		_, res, err := s.stmt.Execute(ctx, x.TxControl(ctx, s.defaultTxControl), x.ToQueryParams(args), x.DataQueryOptions(ctx)...)
+		_, res, err := s.stmt.Execute(ctx, x.TxControl(ctx, s.defaultTxControl), x.ToQueryParams(args), x.DataQueryOptions(ctx, s.dataOpts)...)
 		if err != nil {
 			return nil, errors.Map(err)
 		}
+		x.ReportStats(ctx, res.Stats())
 		return nop.Result(), errors.Map(res.Err())
 	default:
 		return nil, fmt.Errorf("unsupported query mode %s type for execute query", m)
@@ -57,10 +123,46 @@ func (s *stmt) ExecContext(ctx context.Context, args []driver.NamedValue) (drive
 func New(
 	s table.Statement,
 	defaultTxControl *table.TransactionControl,
+	dataOpts []options.ExecuteDataQueryOption,
+	q query.Querier,
+	t trace.Trace,
+	l *querylog.Log,
+	explain querylog.Explain,
+	tx func() tx.Tx,
 ) Stmt {
 	return &stmt{
 		stmt:             s,
 		defaultTxControl: defaultTxControl,
+		dataOpts:         dataOpts,
+		query:            q,
+		trace:            t,
+		log:              l,
+		explain:          explain,
+		tx:               tx,
+	}
+}
+
+// txID returns identifier of transaction which statement executes within or
+// empty string.
+func (s *stmt) txID() string {
+	t := s.tx()
+	if t == nil {
+		return ""
+	}
+	return t.ID()
+}
+
+// entry returns log entry of statement execution. Args of entry are bound
+// args as in entries of queries executed on connection.
+func (s *stmt) entry(ctx context.Context, msg string, args []driver.NamedValue) querylog.Entry {
+	return querylog.Entry{
+		Msg:      msg,
+		Query:    s.stmt.Text(),
+		Mode:     x.QueryMode(ctx).String(),
+		Args:     args,
+		TxID:     s.txID(),
+		Prepared: true,
+		Explain:  s.explain,
 	}
 }
 
@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql/driver"
 	"io"
+	"reflect"
 
 	"github.com/ydb-platform/ydb-go-sdk/v3/table/options"
 	"github.com/ydb-platform/ydb-go-sdk/v3/table/result"
@@ -15,11 +16,18 @@ import (
 type Rows interface {
 	driver.Rows
 	driver.RowsNextResultSet
+	driver.RowsColumnTypeDatabaseTypeName
+	driver.RowsColumnTypeNullable
+	driver.RowsColumnTypeScanType
+	driver.RowsColumnTypePrecisionScale
 }
 
 type rows struct {
 	res result.StreamResult
 	ctx context.Context
+
+	// eof is true when stream is read to the end.
+	eof bool
 }
 
 func (r *rows) HasNextResultSet() bool {
@@ -30,6 +38,7 @@ func (r *rows) NextResultSet() error {
 	if r.res.NextResultSet(r.ctx) {
 		return nil
 	}
+	r.eof = true
 	return io.EOF
 }
 
@@ -54,6 +63,22 @@ func (r *rows) Columns() []string {
 	return cs
 }
 
+func (r *rows) ColumnTypeDatabaseTypeName(index int) string {
+	return x.ColumnTypeDatabaseTypeName(x.Column(r.res.CurrentResultSet(), index))
+}
+
+func (r *rows) ColumnTypeNullable(index int) (nullable, ok bool) {
+	return x.ColumnTypeNullable(x.Column(r.res.CurrentResultSet(), index))
+}
+
+func (r *rows) ColumnTypeScanType(index int) reflect.Type {
+	return x.ColumnTypeScanType(x.Column(r.res.CurrentResultSet(), index))
+}
+
+func (r *rows) ColumnTypePrecisionScale(index int) (precision, scale int64, ok bool) {
+	return x.ColumnTypePrecisionScale(x.Column(r.res.CurrentResultSet(), index))
+}
+
 func (r *rows) Next(dst []driver.Value) (err error) {
 	if !r.res.NextRow() {
 		return io.EOF
@@ -73,5 +98,10 @@ func (r *rows) Next(dst []driver.Value) (err error) {
 }
 
 func (r *rows) Close() error {
+	// Statistics of scan query arrive at the end of stream, so they are
+	// reported only if stream is read to the end.
+	if r.eof && r.res.Err() == nil && x.StatsEnabled(r.ctx) {
+		x.ReportStats(r.ctx, r.res.Stats())
+	}
 	return r.res.Close()
 }
@@ -0,0 +1,70 @@
+package stream
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/result"
+	sdkstats "github.com/ydb-platform/ydb-go-sdk/v3/table/stats"
+
+	"github.com/ydb-platform/ydb-go-sql/internal/stats"
+	"github.com/ydb-platform/ydb-go-sql/internal/x"
+)
+
+// streamResult is a stream of n result sets.
+type streamResult struct {
+	result.StreamResult
+
+	n int
+}
+
+func (r *streamResult) NextResultSet(context.Context, ...string) bool {
+	if r.n == 0 {
+		return false
+	}
+	r.n--
+	return true
+}
+
+func (r *streamResult) Stats() sdkstats.QueryStats { return queryStats{} }
+func (r *streamResult) Err() error                 { return nil }
+func (r *streamResult) Close() error               { return nil }
+
+type queryStats struct{}
+
+func (queryStats) ProcessCPUTime() time.Duration           { return time.Millisecond }
+func (queryStats) Compilation() *sdkstats.CompilationStats { return nil }
+func (queryStats) NextPhase() (sdkstats.QueryPhase, bool)  { return nil, false }
+
+func TestCloseStats(t *testing.T) {
+	var reported int
+	ctx := x.WithStats(context.Background(), func(stats.QueryStats) {
+		reported++
+	})
+
+	res := &streamResult{n: 3}
+	r := Result(ctx, res)
+	if err := r.NextResultSet(); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if res.n != 2 {
+		t.Fatalf("stream is read on close: %d result sets left; want 2", res.n)
+	}
+	if reported != 0 {
+		t.Fatalf("stats of partially read stream are reported")
+	}
+
+	r = Result(ctx, &streamResult{n: 1})
+	for r.NextResultSet() == nil {
+	}
+	if err := r.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if reported != 1 {
+		t.Fatalf("stats of read stream are reported %d times; want 1", reported)
+	}
+}
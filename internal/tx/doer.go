@@ -4,9 +4,8 @@ import (
 	"context"
 	"database/sql"
 
-	"github.com/ydb-platform/ydb-go-sdk/v3/retry"
-
 	"github.com/ydb-platform/ydb-go-sql/internal/errors"
+	"github.com/ydb-platform/ydb-go-sql/internal/retry"
 )
 
 type TxOperationFunc func(context.Context, *sql.Tx) error
@@ -15,40 +14,44 @@ type TxOperationFunc func(context.Context, *sql.Tx) error
 type TxDoer struct {
 	DB      *sql.DB
 	Options *sql.TxOptions
+
+	// RetryOptions configures retry policy. If empty, transaction retried
+	// until context is done with default backoffs and idempotency flag
+	// from context.
+	RetryOptions []retry.Option
 }
 
 // Do starts a transaction and calls f with it. If f() call returns a retryable
-// error, it repeats it accordingly to retry configuration that TxDoer's DB
-// driver holds.
+// error, it repeats it accordingly to retry configuration that TxDoer holds.
 //
 // Note that callers should mutate state outside f carefully and keeping in
 // mind that f could be called again even if no error returned – transaction
 // commitment can be failed:
 //
-//   var results []int
-//   ydb.DoTx(x, db, TxOperationFunc(func(x context.Context, tx *conn.Tx) error {
-//       // Reset resulting slice to prevent duplicates when retry occurred.
-//       results = results[:0]
+//	var results []int
+//	ydb.DoTx(x, db, TxOperationFunc(func(x context.Context, tx *conn.Tx) error {
+//	    // Reset resulting slice to prevent duplicates when retry occurred.
+//	    results = results[:0]
 //
-//       rows, err := tx.QueryContext(...)
-//       if err != nil {
-//           // handle error
-//       }
-//       for rows.Next() {
-//           results = append(results, ...)
-//       }
-//       return rows.Err()
-//   }))
+//	    rows, err := tx.QueryContext(...)
+//	    if err != nil {
+//	        // handle error
+//	    }
+//	    for rows.Next() {
+//	        results = append(results, ...)
+//	    }
+//	    return rows.Err()
+//	}))
 func (d TxDoer) Do(ctx context.Context, f TxOperationFunc) (err error) {
-	return errors.Map(retry.Retry(ctx, retry.IsOperationIdempotent(ctx), func(ctx context.Context) (err error) {
+	return errors.Map(retry.Retry(ctx, func(ctx context.Context) (err error) {
 		return d.do(ctx, f)
-	}))
+	}, d.RetryOptions...))
 }
 
 func (d TxDoer) do(ctx context.Context, f TxOperationFunc) error {
 	tx, err := d.DB.BeginTx(ctx, d.Options)
 	if err != nil {
-		return err
+		return retry.NotExecuted(err)
 	}
 	defer func() {
 		_ = tx.Rollback()
@@ -62,6 +65,6 @@ func (d TxDoer) do(ctx context.Context, f TxOperationFunc) error {
 
 // DoTx is a shortcut for calling Do(x, f) on initialized TxDoer with DB
 // field set to given db.
-func DoTx(ctx context.Context, db *sql.DB, f TxOperationFunc) error {
-	return (TxDoer{DB: db}).Do(ctx, f)
+func DoTx(ctx context.Context, db *sql.DB, f TxOperationFunc, opts ...retry.Option) error {
+	return (TxDoer{DB: db, RetryOptions: opts}).Do(ctx, f)
 }
@@ -0,0 +1,46 @@
+package tx
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"testing"
+
+	"github.com/ydb-platform/ydb-go-sql/internal/retry"
+)
+
+type stubConnector struct {
+	commit error
+}
+
+func (c stubConnector) Connect(context.Context) (driver.Conn, error) { return stubConn(c), nil }
+func (stubConnector) Driver() driver.Driver                          { return nil }
+
+type stubConn stubConnector
+
+func (stubConn) Prepare(string) (driver.Stmt, error) { return nil, driver.ErrSkip }
+func (stubConn) Close() error                        { return nil }
+func (c stubConn) Begin() (driver.Tx, error)         { return stubTx(c), nil }
+
+type stubTx stubConn
+
+func (t stubTx) Commit() error { return t.commit }
+func (stubTx) Rollback() error { return nil }
+
+func TestDoTxBadConnAfterCommit(t *testing.T) {
+	db := sql.OpenDB(stubConnector{commit: driver.ErrBadConn})
+	defer func() {
+		_ = db.Close()
+	}()
+	var attempts int
+	err := DoTx(context.Background(), db, func(context.Context, *sql.Tx) error {
+		attempts++
+		return nil
+	}, retry.WithIdempotent(false))
+	if err != driver.ErrBadConn {
+		t.Fatalf("unexpected error: %v; want %v", err, driver.ErrBadConn)
+	}
+	if attempts != 1 {
+		t.Fatalf("unexpected attempts: %d; want 1", attempts)
+	}
+}
@@ -0,0 +1,53 @@
+package tx
+
+import (
+	"context"
+
+	"github.com/ydb-platform/ydb-go-sql/internal/errors"
+	"github.com/ydb-platform/ydb-go-sql/internal/querylog"
+)
+
+// WithLog returns Tx which logs commit and rollback calls into l. Commit and
+// Rollback calls are logged as CommitContext and RollbackContext with context
+// of BeginTx call ctx. WithLog returns tx as is if l is nil.
+func WithLog(ctx context.Context, tx Tx, l *querylog.Log) Tx {
+	if l == nil {
+		return tx
+	}
+	return &logged{Tx: tx, ctx: ctx, l: l}
+}
+
+type logged struct {
+	Tx
+
+	ctx context.Context
+	l   *querylog.Log
+}
+
+func (tx *logged) Commit() error {
+	return tx.CommitContext(tx.ctx)
+}
+
+func (tx *logged) Rollback() error {
+	return tx.RollbackContext(tx.ctx)
+}
+
+func (tx *logged) CommitContext(ctx context.Context) (err error) {
+	onDone := tx.l.Start(ctx, querylog.Entry{Msg: "ydb: commit", TxID: tx.ID()})
+	defer func() {
+		onDone(-1, err)
+	}()
+	return tx.Tx.CommitContext(ctx)
+}
+
+func (tx *logged) RollbackContext(ctx context.Context) (err error) {
+	onDone := tx.l.Start(ctx, querylog.Entry{Msg: "ydb: rollback", TxID: tx.ID()})
+	defer func() {
+		// Rollback of finished transaction is not logged, e.g. rollback of
+		// *sql.Tx after ydb.CommitContext.
+		if err != errors.ErrTxCommitted && err != errors.ErrTxRolledBack {
+			onDone(-1, err)
+		}
+	}()
+	return tx.Tx.RollbackContext(ctx)
+}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 
 	"github.com/ydb-platform/ydb-go-sdk/v3/table"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/options"
 
 	"github.com/ydb-platform/ydb-go-sql/internal/errors"
 	"github.com/ydb-platform/ydb-go-sql/internal/mode"
@@ -16,18 +17,25 @@ import (
 )
 
 type ro struct {
-	s     table.ClosableSession
-	txc   *table.TransactionControl
-	close func()
+	lifecycle
+
+	s   table.ClosableSession
+	txc *table.TransactionControl
+
+	dataOpts []options.ExecuteDataQueryOption
 }
 
 func (tx *ro) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	if err := tx.err(); err != nil {
+		return nil, err
+	}
 	switch m := x.QueryMode(ctx); m {
 	case mode.DataQuery:
-		_, res, err := tx.s.Execute(ctx, tx.txc, query, x.ToQueryParams(args))
+		_, res, err := tx.s.Execute(ctx, tx.txc, query, x.ToQueryParams(args), x.DataQueryOptions(ctx, tx.dataOpts)...)
 		if err != nil {
 			return nil, errors.Map(err)
 		}
+		x.ReportStats(ctx, res.Stats())
 		return rows.Result(res), nil
 	case mode.ExplainQuery:
 		exp, err := tx.s.Explain(ctx, query)
@@ -44,15 +52,60 @@ func (tx *ro) QueryContext(ctx context.Context, query string, args []driver.Name
 }
 
 func (tx *ro) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	if err := tx.err(); err != nil {
+		return nil, err
+	}
+	return nil, errors.ErrExecOnReadOnlyTx
+}
+
+func (tx *ro) StmtQueryContext(ctx context.Context, stmt table.Statement, args []driver.NamedValue) (driver.Rows, error) {
+	if err := tx.err(); err != nil {
+		return nil, err
+	}
+	switch m := x.QueryMode(ctx); m {
+	case mode.DataQuery:
+		_, res, err := stmt.Execute(ctx, tx.txc, x.ToQueryParams(args), x.DataQueryOptions(ctx, tx.dataOpts)...)
+		if err != nil {
+			return nil, errors.Map(err)
+		}
+		x.ReportStats(ctx, res.Stats())
+		return rows.Result(res), errors.Map(res.Err())
+	default:
+		return nil, fmt.Errorf("unsupported query mode %s type for execute statement query on ro tx", m)
+	}
+}
+
+func (tx *ro) StmtExecContext(ctx context.Context, stmt table.Statement, args []driver.NamedValue) (driver.Result, error) {
+	if err := tx.err(); err != nil {
+		return nil, err
+	}
 	return nil, errors.ErrExecOnReadOnlyTx
 }
 
+func (tx *ro) ID() string {
+	return ""
+}
+
+func (tx *ro) CommitContext(context.Context) error {
+	return tx.Commit()
+}
+
+func (tx *ro) RollbackContext(context.Context) error {
+	return tx.Rollback()
+}
+
 func (tx *ro) Commit() error {
-	defer tx.close()
+	if tx.done {
+		return tx.err()
+	}
+	tx.finish(stateCommitted, nil)
 	return nil
 }
 
 func (tx *ro) Rollback() error {
-	defer tx.close()
+	if tx.done {
+		return tx.err()
+	}
+	tx.finish(stateRolledBack, nil)
 	return nil
 }
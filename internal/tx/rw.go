@@ -5,8 +5,10 @@ import (
 	"database/sql"
 	"database/sql/driver"
 	"fmt"
+	"time"
 
 	"github.com/ydb-platform/ydb-go-sdk/v3/table"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/options"
 
 	"github.com/ydb-platform/ydb-go-sql/internal/errors"
 	"github.com/ydb-platform/ydb-go-sql/internal/mode"
@@ -16,20 +18,33 @@ import (
 	"github.com/ydb-platform/ydb-go-sql/internal/x"
 )
 
+// rollbackTimeout bounds rollback of transaction which context is done.
+const rollbackTimeout = 5 * time.Second
+
 type rw struct {
-	s     table.ClosableSession
-	tx    table.Transaction
-	txc   *table.TransactionControl
-	close func()
+	lifecycle
+
+	// ctx is a context of BeginTx call which is used for Commit and Rollback.
+	ctx context.Context
+
+	s   table.ClosableSession
+	tx  table.Transaction
+	txc *table.TransactionControl
+
+	dataOpts []options.ExecuteDataQueryOption
 }
 
 func (tx *rw) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	if err := tx.err(); err != nil {
+		return nil, err
+	}
 	switch m := x.QueryMode(ctx); m {
 	case mode.DataQuery:
-		res, err := tx.tx.Execute(ctx, query, x.ToQueryParams(args))
+		res, err := tx.tx.Execute(ctx, query, x.ToQueryParams(args), x.DataQueryOptions(ctx, tx.dataOpts)...)
 		if err != nil {
-			return nil, errors.Map(err)
+			return nil, tx.check(err)
 		}
+		x.ReportStats(ctx, res.Stats())
 		return rows.Result(res), nil
 	case mode.ExplainQuery:
 		exp, err := tx.s.Explain(ctx, query)
@@ -46,25 +61,101 @@ func (tx *rw) QueryContext(ctx context.Context, query string, args []driver.Name
 }
 
 func (tx *rw) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
-	res, err := tx.tx.Execute(ctx, query, x.ToQueryParams(args))
+	if err := tx.err(); err != nil {
+		return nil, err
+	}
+	res, err := tx.tx.Execute(ctx, query, x.ToQueryParams(args), x.DataQueryOptions(ctx, tx.dataOpts)...)
 	if err != nil {
-		return nil, errors.Map(err)
+		return nil, tx.check(err)
+	}
+	x.ReportStats(ctx, res.Stats())
+	return nop.Result(), tx.check(res.Err())
+}
+
+func (tx *rw) StmtQueryContext(ctx context.Context, stmt table.Statement, args []driver.NamedValue) (driver.Rows, error) {
+	if err := tx.err(); err != nil {
+		return nil, err
+	}
+	switch m := x.QueryMode(ctx); m {
+	case mode.DataQuery:
+		res, err := tx.tx.ExecuteStatement(ctx, stmt, x.ToQueryParams(args), x.DataQueryOptions(ctx, tx.dataOpts)...)
+		if err != nil {
+			return nil, tx.check(err)
+		}
+		x.ReportStats(ctx, res.Stats())
+		return rows.Result(res), tx.check(res.Err())
+	default:
+		return nil, fmt.Errorf("unsupported query mode %s type for execute statement query on rw tx", m)
+	}
+}
+
+func (tx *rw) StmtExecContext(ctx context.Context, stmt table.Statement, args []driver.NamedValue) (driver.Result, error) {
+	if err := tx.err(); err != nil {
+		return nil, err
+	}
+	switch m := x.QueryMode(ctx); m {
+	case mode.DataQuery:
+		res, err := tx.tx.ExecuteStatement(ctx, stmt, x.ToQueryParams(args), x.DataQueryOptions(ctx, tx.dataOpts)...)
+		if err != nil {
+			return nil, tx.check(err)
+		}
+		x.ReportStats(ctx, res.Stats())
+		return nop.Result(), tx.check(res.Err())
+	default:
+		return nil, fmt.Errorf("unsupported query mode %s type for execute statement on rw tx", m)
+	}
+}
+
+func (tx *rw) ID() string {
+	return tx.tx.ID()
+}
+
+func (tx *rw) Commit() error {
+	return tx.CommitContext(tx.ctx)
+}
+
+func (tx *rw) Rollback() error {
+	return tx.RollbackContext(tx.ctx)
+}
+
+func (tx *rw) CommitContext(ctx context.Context) (err error) {
+	if tx.done {
+		return tx.err()
 	}
-	return nop.Result(), errors.Map(res.Err())
+	if err = tx.err(); err != nil {
+		// Server already invalidated transaction, so rollback is just a
+		// best effort to release it.
+		tx.rollback(ctx)
+		tx.finish(stateBroken, nil)
+		return err
+	}
+	_, err = tx.tx.CommitTx(ctx)
+	tx.finish(stateCommitted, err)
+	return errors.Map(err)
 }
 
-func (tx *rw) Commit() (err error) {
-	_, err = tx.tx.CommitTx(context.Background())
-	if err == nil {
-		tx.close()
+func (tx *rw) RollbackContext(ctx context.Context) (err error) {
+	if tx.done {
+		return tx.err()
+	}
+	if tx.state == stateBroken || ctx.Err() != nil {
+		tx.rollback(ctx)
+		tx.finish(stateRolledBack, nil)
+		return nil
 	}
-	return err
+	err = tx.tx.Rollback(ctx)
+	tx.finish(stateRolledBack, err)
+	return errors.Map(err)
 }
 
-func (tx *rw) Rollback() (err error) {
-	err = tx.tx.Rollback(context.Background())
-	if err == nil {
-		tx.close()
+// rollback makes best effort rollback of transaction. If ctx is done rollback
+// is made with detached context bounded by rollbackTimeout, so session is not
+// used after rollback returns.
+func (tx *rw) rollback(ctx context.Context) {
+	if ctx.Err() != nil {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(context.Background(), rollbackTimeout)
+		defer cancel()
 	}
-	return err
+	_ = tx.tx.Rollback(ctx)
 }
@@ -0,0 +1,86 @@
+package tx
+
+import (
+	"fmt"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3"
+
+	"github.com/ydb-platform/ydb-go-sql/internal/errors"
+)
+
+// state is a transaction lifecycle state.
+type state uint8
+
+const (
+	stateActive state = iota
+	stateCommitted
+	stateRolledBack
+	stateBroken
+)
+
+func (s state) String() string {
+	switch s {
+	case stateActive:
+		return "active"
+	case stateCommitted:
+		return "committed"
+	case stateRolledBack:
+		return "rolled_back"
+	case stateBroken:
+		return "broken"
+	default:
+		return fmt.Sprintf("unknown_tx_state_%d", s)
+	}
+}
+
+// lifecycle tracks state of transaction shared by ro and rw transactions.
+// Once transaction finished it detaches from connection through close
+// callback regardless of finish result.
+type lifecycle struct {
+	state state
+	done  bool
+
+	// cause is an error which broke transaction.
+	cause error
+
+	close func(err error)
+}
+
+// err returns error for using transaction in current state or nil if
+// transaction is active.
+func (l *lifecycle) err() error {
+	switch l.state {
+	case stateActive:
+		return nil
+	case stateCommitted:
+		return errors.ErrTxCommitted
+	case stateRolledBack:
+		return errors.ErrTxRolledBack
+	default:
+		return fmt.Errorf("%w: %v", errors.ErrTxBroken, l.cause)
+	}
+}
+
+// check maps an error of query within transaction and marks transaction as
+// broken if error invalidates transaction on server side.
+func (l *lifecycle) check(err error) error {
+	if err == nil {
+		return nil
+	}
+	if l.state == stateActive && (ydb.IsOperationError(err) || ydb.IsTransportError(err)) {
+		l.state = stateBroken
+		l.cause = err
+	}
+	return errors.Map(err)
+}
+
+// finish moves transaction to final state s or to broken state if err is not
+// nil and detaches transaction from connection.
+func (l *lifecycle) finish(s state, err error) {
+	if err != nil {
+		s, l.cause = stateBroken, err
+	}
+	l.state = s
+	l.done = true
+	l.close(l.cause)
+}
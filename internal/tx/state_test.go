@@ -0,0 +1,153 @@
+package tx
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"testing"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/table"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/options"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/result"
+
+	internal "github.com/ydb-platform/ydb-go-sql/internal/errors"
+)
+
+func TestReadOnlyLifecycle(t *testing.T) {
+	for _, test := range []struct {
+		name   string
+		finish func(Tx) error
+		err    error
+	}{
+		{
+			name:   "commit",
+			finish: func(tx Tx) error { return tx.Commit() },
+			err:    internal.ErrTxCommitted,
+		},
+		{
+			name:   "rollback",
+			finish: func(tx Tx) error { return tx.Rollback() },
+			err:    internal.ErrTxRolledBack,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			var closed int
+			tx := &ro{
+				lifecycle: lifecycle{close: func(err error) {
+					if err != nil {
+						t.Fatalf("unexpected close error: %v", err)
+					}
+					closed++
+				}},
+			}
+			if err := test.finish(tx); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if closed != 1 {
+				t.Fatalf("unexpected close calls: %d; want 1", closed)
+			}
+			if err := test.finish(tx); !errors.Is(err, test.err) {
+				t.Fatalf("unexpected error on second finish: %v; want %v", err, test.err)
+			}
+			if _, err := tx.QueryContext(context.Background(), "SELECT 1", nil); !errors.Is(err, test.err) {
+				t.Fatalf("unexpected error on query: %v; want %v", err, test.err)
+			}
+			if closed != 1 {
+				t.Fatalf("unexpected close calls: %d; want 1", closed)
+			}
+		})
+	}
+}
+
+func TestLifecycleFinishWithError(t *testing.T) {
+	var cause error
+	l := lifecycle{close: func(err error) {
+		cause = err
+	}}
+	l.finish(stateCommitted, driver.ErrBadConn)
+	if cause != driver.ErrBadConn {
+		t.Fatalf("unexpected close error: %v; want %v", cause, driver.ErrBadConn)
+	}
+	if err := l.err(); !errors.Is(err, internal.ErrTxBroken) {
+		t.Fatalf("unexpected error: %v; want %v", err, internal.ErrTxBroken)
+	}
+}
+
+type ctxKey struct{}
+
+type fakeTx struct {
+	table.Transaction
+
+	commit   func(ctx context.Context) error
+	rollback func(ctx context.Context) error
+}
+
+func (tx *fakeTx) CommitTx(ctx context.Context, _ ...options.CommitTransactionOption) (result.Result, error) {
+	return nil, tx.commit(ctx)
+}
+
+func (tx *fakeTx) Rollback(ctx context.Context) error {
+	return tx.rollback(ctx)
+}
+
+func TestCommitWithBeginContext(t *testing.T) {
+	ctx := context.WithValue(context.Background(), ctxKey{}, true)
+	tx := &rw{
+		lifecycle: lifecycle{close: func(error) {}},
+		ctx:       ctx,
+		tx: &fakeTx{
+			commit: func(ctx context.Context) error {
+				if ctx.Value(ctxKey{}) == nil {
+					t.Fatalf("commit with unexpected context")
+				}
+				return nil
+			},
+		},
+	}
+	if err := tx.Commit(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestRollbackOnDoneContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	var (
+		rollback int
+		cause    error
+	)
+	tx := &rw{
+		lifecycle: lifecycle{close: func(err error) {
+			cause = err
+		}},
+		ctx: ctx,
+		tx: &fakeTx{
+			rollback: func(ctx context.Context) error {
+				if err := ctx.Err(); err != nil {
+					t.Fatalf("rollback with done context: %v", err)
+				}
+				if _, ok := ctx.Deadline(); !ok {
+					t.Fatalf("rollback without deadline")
+				}
+				rollback++
+				return nil
+			},
+		},
+	}
+	if err := tx.Rollback(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if rollback != 1 {
+		t.Fatalf("unexpected rollback calls: %d; want 1", rollback)
+	}
+	if cause != nil {
+		t.Fatalf("unexpected close error: %v", cause)
+	}
+}
+
+func TestReadOnlyStmtExec(t *testing.T) {
+	tx := &ro{lifecycle: lifecycle{close: func(error) {}}}
+	if _, err := tx.StmtExecContext(context.Background(), nil, nil); !errors.Is(err, internal.ErrExecOnReadOnlyTx) {
+		t.Fatalf("unexpected error: %v; want %v", err, internal.ErrExecOnReadOnlyTx)
+	}
+}
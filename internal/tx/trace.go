@@ -0,0 +1,45 @@
+package tx
+
+import (
+	"context"
+
+	"github.com/ydb-platform/ydb-go-sql/trace"
+)
+
+// WithTrace returns Tx which calls commit and rollback hooks of t. Commit and
+// Rollback calls are traced as CommitContext and RollbackContext with context
+// of BeginTx call ctx.
+func WithTrace(ctx context.Context, tx Tx, t trace.Trace) Tx {
+	return &traced{Tx: tx, ctx: ctx, t: t}
+}
+
+type traced struct {
+	Tx
+
+	ctx context.Context
+	t   trace.Trace
+}
+
+func (tx *traced) Commit() error {
+	return tx.CommitContext(tx.ctx)
+}
+
+func (tx *traced) Rollback() error {
+	return tx.RollbackContext(tx.ctx)
+}
+
+func (tx *traced) CommitContext(ctx context.Context) (err error) {
+	onDone := trace.TraceOnCommit(ctx, tx.t, &ctx, tx.ID())
+	defer func() {
+		onDone(err)
+	}()
+	return tx.Tx.CommitContext(ctx)
+}
+
+func (tx *traced) RollbackContext(ctx context.Context) (err error) {
+	onDone := trace.TraceOnRollback(ctx, tx.t, &ctx, tx.ID())
+	defer func() {
+		onDone(err)
+	}()
+	return tx.Tx.RollbackContext(ctx)
+}
@@ -5,6 +5,7 @@ import (
 	"database/sql/driver"
 
 	"github.com/ydb-platform/ydb-go-sdk/v3/table"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/options"
 
 	"github.com/ydb-platform/ydb-go-sql/internal/errors"
 )
@@ -13,28 +14,55 @@ type Tx interface {
 	driver.Tx
 	driver.QueryerContext
 	driver.ExecerContext
+
+	CommitContext(ctx context.Context) error
+	RollbackContext(ctx context.Context) error
+
+	// ID returns identifier of transaction. Read-only transactions have no
+	// identifiers on server, so ID returns empty string for them.
+	ID() string
+
+	// StmtQueryContext and StmtExecContext execute prepared statement within
+	// transaction.
+	StmtQueryContext(ctx context.Context, stmt table.Statement, args []driver.NamedValue) (driver.Rows, error)
+	StmtExecContext(ctx context.Context, stmt table.Statement, args []driver.NamedValue) (driver.Result, error)
 }
 
-func New(ctx context.Context, opts driver.TxOptions, s table.ClosableSession, close func()) (Tx, error) {
+// New begins transaction on session s. Transaction is begun with context
+// begin while ctx is a context of transaction used by Commit and Rollback
+// (they differ when begin is replaced by trace hook). Queries within
+// transaction executed with default options dataOpts. Callback close is called
+// once transaction finished with an error which broke transaction or nil.
+func New(
+	ctx context.Context,
+	begin context.Context,
+	opts driver.TxOptions,
+	s table.ClosableSession,
+	dataOpts []options.ExecuteDataQueryOption,
+	close func(err error),
+) (Tx, error) {
 	isolation, control, err := isolationOrControl(opts)
 	if err != nil {
 		return nil, err
 	}
 	if isolation == nil {
 		return &ro{
-			s:     s,
-			txc:   table.TxControl(control...),
-			close: close,
+			lifecycle: lifecycle{close: close},
+			s:         s,
+			txc:       table.TxControl(control...),
+			dataOpts:  dataOpts,
 		}, nil
 	}
-	tx, err := s.BeginTransaction(ctx, table.TxSettings(isolation))
+	tx, err := s.BeginTransaction(begin, table.TxSettings(isolation))
 	if err != nil {
 		return nil, errors.Map(err)
 	}
 	return &rw{
-		s:     s,
-		tx:    tx,
-		txc:   table.TxControl(append(control, table.WithTx(tx))...),
-		close: close,
+		lifecycle: lifecycle{close: close},
+		ctx:       ctx,
+		s:         s,
+		tx:        tx,
+		txc:       table.TxControl(append(control, table.WithTx(tx))...),
+		dataOpts:  dataOpts,
 	}, nil
 }
@@ -0,0 +1,174 @@
+package x
+
+import (
+	"bytes"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/options"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/result"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/types"
+
+	"github.com/ydb-platform/ydb-go-sql/internal/decimal"
+)
+
+// scanTypes maps names of YDB primitive types to Go types of values returned
+// by Any.
+var scanTypes = map[string]reflect.Type{
+	"Bool":         reflect.TypeOf(false),
+	"Int8":         reflect.TypeOf(int64(0)),
+	"Int16":        reflect.TypeOf(int64(0)),
+	"Int32":        reflect.TypeOf(int64(0)),
+	"Int64":        reflect.TypeOf(int64(0)),
+	"Uint8":        reflect.TypeOf(int64(0)),
+	"Uint16":       reflect.TypeOf(int64(0)),
+	"Uint32":       reflect.TypeOf(int64(0)),
+	"Uint64":       reflect.TypeOf(uint64(0)),
+	"Float":        reflect.TypeOf(float64(0)),
+	"Double":       reflect.TypeOf(float64(0)),
+	"Date":         reflect.TypeOf(time.Time{}),
+	"Datetime":     reflect.TypeOf(time.Time{}),
+	"Timestamp":    reflect.TypeOf(time.Time{}),
+	"TzDate":       reflect.TypeOf(time.Time{}),
+	"TzDatetime":   reflect.TypeOf(time.Time{}),
+	"TzTimestamp":  reflect.TypeOf(time.Time{}),
+	"Interval":     reflect.TypeOf(time.Duration(0)),
+	"String":       reflect.TypeOf([]byte(nil)),
+	"Yson":         reflect.TypeOf([]byte(nil)),
+	"Json":         reflect.TypeOf([]byte(nil)),
+	"JsonDocument": reflect.TypeOf([]byte(nil)),
+	"Utf8":         reflect.TypeOf(""),
+	"DyNumber":     reflect.TypeOf(""),
+	"Uuid":         reflect.TypeOf([16]byte{}),
+}
+
+// nullScanTypes maps scan types of nullable columns to sql.NullXxx types.
+var nullScanTypes = map[reflect.Type]reflect.Type{
+	reflect.TypeOf(false):       reflect.TypeOf(sql.NullBool{}),
+	reflect.TypeOf(int64(0)):    reflect.TypeOf(sql.NullInt64{}),
+	reflect.TypeOf(float64(0)):  reflect.TypeOf(sql.NullFloat64{}),
+	reflect.TypeOf(""):          reflect.TypeOf(sql.NullString{}),
+	reflect.TypeOf(time.Time{}): reflect.TypeOf(sql.NullTime{}),
+}
+
+var (
+	typeValue   = reflect.TypeOf((*types.Value)(nil)).Elem()
+	typeDecimal = reflect.TypeOf(decimal.Decimal{})
+)
+
+// TypeAny is a scan type of columns which values could not be scanned into
+// more specific Go type.
+var TypeAny = reflect.TypeOf((*interface{})(nil)).Elem()
+
+// Column returns index-th column of set.
+func Column(set result.Set, index int) (c options.Column) {
+	var i int
+	set.Columns(func(m options.Column) {
+		if i == index {
+			c = m
+		}
+		i++
+	})
+	return c
+}
+
+// typeName returns YDB type name of t without Optional wrapping and reports
+// whether t is Optional.
+func typeName(t types.Type) (name string, optional bool) {
+	var buf bytes.Buffer
+	types.WriteTypeStringTo(&buf, t)
+	name = buf.String()
+	if strings.HasPrefix(name, "Optional<") && strings.HasSuffix(name, ">") {
+		return name[len("Optional<") : len(name)-1], true
+	}
+	return name, false
+}
+
+// ColumnTypeDatabaseTypeName returns YDB type name of column with upper-cased
+// keywords without Optional wrapping, e.g. UTF8, LIST<INT64>, DECIMAL(22,9) or
+// STRUCT<id:UINT64>.
+func ColumnTypeDatabaseTypeName(c options.Column) string {
+	name, _ := typeName(c.Type)
+	return upperKeywords(name)
+}
+
+// upperKeywords upper-cases type keywords of type name. Names of Struct
+// members and Variant labels, which are followed by colon, are kept as is.
+func upperKeywords(name string) string {
+	var b strings.Builder
+	for i := 0; i < len(name); {
+		j := i
+		for j < len(name) && isIdent(name[j]) {
+			j++
+		}
+		switch {
+		case j == i:
+			b.WriteByte(name[i])
+			j++
+		case j < len(name) && name[j] == ':':
+			b.WriteString(name[i:j])
+		default:
+			b.WriteString(strings.ToUpper(name[i:j]))
+		}
+		i = j
+	}
+	return b.String()
+}
+
+func isIdent(c byte) bool {
+	return c == '_' || '0' <= c && c <= '9' || 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z'
+}
+
+// ColumnTypeNullable reports whether column has Optional type.
+func ColumnTypeNullable(c options.Column) (nullable, ok bool) {
+	_, optional := typeName(c.Type)
+	return optional, true
+}
+
+// ColumnTypeScanType returns Go type of column values returned by Any. Scan
+// type of nullable column is sql.NullXxx type if there is one or pointer to
+// type of values otherwise. Slices and interfaces hold nil as is.
+func ColumnTypeScanType(c options.Column) reflect.Type {
+	name, optional := typeName(c.Type)
+	t := scanType(name)
+	if !optional {
+		return t
+	}
+	if nt, ok := nullScanTypes[t]; ok {
+		return nt
+	}
+	switch t.Kind() {
+	case reflect.Slice, reflect.Interface:
+		return t
+	default:
+		return reflect.PtrTo(t)
+	}
+}
+
+func scanType(name string) reflect.Type {
+	if t, ok := scanTypes[name]; ok {
+		return t
+	}
+	if name == "Void" {
+		return TypeAny
+	}
+	if strings.HasPrefix(name, "Decimal(") {
+		return typeDecimal
+	}
+	return typeValue
+}
+
+// ColumnTypePrecisionScale returns precision and scale of Decimal column.
+func ColumnTypePrecisionScale(c options.Column) (precision, scale int64, ok bool) {
+	name, _ := typeName(c.Type)
+	if !strings.HasPrefix(name, "Decimal(") {
+		return 0, 0, false
+	}
+	if _, err := fmt.Sscanf(name, "Decimal(%d,%d)", &precision, &scale); err != nil {
+		return 0, 0, false
+	}
+	return precision, scale, true
+}
@@ -0,0 +1,107 @@
+package x
+
+import (
+	"database/sql"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/options"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/types"
+
+	"github.com/ydb-platform/ydb-go-sql/internal/decimal"
+)
+
+func TestColumnType(t *testing.T) {
+	for _, test := range []struct {
+		t         types.Type
+		name      string
+		nullable  bool
+		scanType  reflect.Type
+		precision int64
+		scale     int64
+		decimal   bool
+	}{
+		{
+			t:        types.TypeUint64,
+			name:     "UINT64",
+			scanType: reflect.TypeOf(uint64(0)),
+		},
+		{
+			t:        types.Optional(types.TypeUTF8),
+			name:     "UTF8",
+			nullable: true,
+			scanType: reflect.TypeOf(sql.NullString{}),
+		},
+		{
+			t:        types.Optional(types.TypeDate),
+			name:     "DATE",
+			nullable: true,
+			scanType: reflect.TypeOf(sql.NullTime{}),
+		},
+		{
+			t:        types.Optional(types.TypeUint64),
+			name:     "UINT64",
+			nullable: true,
+			scanType: reflect.TypeOf((*uint64)(nil)),
+		},
+		{
+			t:        types.Optional(types.TypeString),
+			name:     "STRING",
+			nullable: true,
+			scanType: reflect.TypeOf([]byte(nil)),
+		},
+		{
+			t:        types.TypeDate,
+			name:     "DATE",
+			scanType: reflect.TypeOf(time.Time{}),
+		},
+		{
+			t:        types.List(types.TypeInt64),
+			name:     "LIST<INT64>",
+			scanType: reflect.TypeOf((*types.Value)(nil)).Elem(),
+		},
+		{
+			t: types.Struct(
+				types.StructField("series_id", types.TypeUint64),
+				types.StructField("List", types.Optional(types.TypeUTF8)),
+			),
+			name:     "STRUCT<series_id:UINT64,List:OPTIONAL<UTF8>>",
+			scanType: reflect.TypeOf((*types.Value)(nil)).Elem(),
+		},
+		{
+			t:        types.Variant(types.Struct(types.StructField("foo", types.TypeInt32))),
+			name:     "VARIANT<STRUCT<foo:INT32>>",
+			scanType: reflect.TypeOf((*types.Value)(nil)).Elem(),
+		},
+		{
+			t:         types.Optional(types.DecimalType(22, 9)),
+			name:      "DECIMAL(22,9)",
+			nullable:  true,
+			scanType:  reflect.TypeOf(&decimal.Decimal{}),
+			precision: 22,
+			scale:     9,
+			decimal:   true,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			c := options.Column{Name: "c", Type: test.t}
+			if act := ColumnTypeDatabaseTypeName(c); act != test.name {
+				t.Errorf("unexpected type name: %q; want %q", act, test.name)
+			}
+			if act, ok := ColumnTypeNullable(c); !ok || act != test.nullable {
+				t.Errorf("unexpected nullable: %v, %v; want %v", act, ok, test.nullable)
+			}
+			if act := ColumnTypeScanType(c); act != test.scanType {
+				t.Errorf("unexpected scan type: %v; want %v", act, test.scanType)
+			}
+			precision, scale, ok := ColumnTypePrecisionScale(c)
+			if ok != test.decimal || precision != test.precision || scale != test.scale {
+				t.Errorf(
+					"unexpected precision and scale: %d, %d, %v; want %d, %d, %v",
+					precision, scale, ok, test.precision, test.scale, test.decimal,
+				)
+			}
+		})
+	}
+}
@@ -3,10 +3,13 @@ package x
 import (
 	"context"
 
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb_Table"
 	"github.com/ydb-platform/ydb-go-sdk/v3/table"
 	"github.com/ydb-platform/ydb-go-sdk/v3/table/options"
+	sdkstats "github.com/ydb-platform/ydb-go-sdk/v3/table/stats"
 
 	"github.com/ydb-platform/ydb-go-sql/internal/mode"
+	"github.com/ydb-platform/ydb-go-sql/internal/stats"
 )
 
 type (
@@ -14,6 +17,9 @@ type (
 	ctxDataQueryOptionsKey   struct{}
 	ctxScanQueryOptionsKey   struct{}
 	ctxModeTypeKey           struct{}
+	ctxAutoDeclareKey        struct{}
+	ctxQueryTagsKey          struct{}
+	ctxStatsKey              struct{}
 )
 
 func WithTxControl(ctx context.Context, txc *table.TransactionControl) context.Context {
@@ -28,27 +34,87 @@ func TxControl(ctx context.Context, defaultTxControl *table.TransactionControl)
 }
 
 func WithScanQueryOptions(ctx context.Context, opts []options.ExecuteScanQueryOption) context.Context {
-	return context.WithValue(ctx, ctxScanQueryOptionsKey{}, append(ScanQueryOptions(ctx), opts...))
+	return context.WithValue(ctx, ctxScanQueryOptionsKey{}, mergeScanQueryOptions(scanQueryOptions(ctx), opts))
 }
 
-func ScanQueryOptions(ctx context.Context) []options.ExecuteScanQueryOption {
+// ScanQueryOptions returns default options merged with options from context.
+// If context contains stats callback, collecting of statistics is enabled in
+// basic mode unless options already collect them in more detailed mode.
+func ScanQueryOptions(ctx context.Context, defaults []options.ExecuteScanQueryOption) []options.ExecuteScanQueryOption {
+	opts := mergeScanQueryOptions(defaults, scanQueryOptions(ctx))
+	if StatsEnabled(ctx) {
+		var d options.ExecuteScanQueryDesc
+		for _, opt := range opts {
+			opt(&d)
+		}
+		if d.CollectStats < Ydb_Table.QueryStatsCollection_STATS_COLLECTION_BASIC {
+			opts = append(opts[:len(opts):len(opts)], withScanCollectStatsModeBasic)
+		}
+	}
+	return opts
+}
+
+func withScanCollectStatsModeBasic(d *options.ExecuteScanQueryDesc) {
+	d.CollectStats = Ydb_Table.QueryStatsCollection_STATS_COLLECTION_BASIC
+}
+
+func scanQueryOptions(ctx context.Context) []options.ExecuteScanQueryOption {
 	if opts, ok := ctx.Value(ctxScanQueryOptionsKey{}).([]options.ExecuteScanQueryOption); ok {
 		return opts
 	}
 	return nil
 }
 
+func mergeScanQueryOptions(a, b []options.ExecuteScanQueryOption) []options.ExecuteScanQueryOption {
+	switch {
+	case len(a) == 0:
+		return b
+	case len(b) == 0:
+		return a
+	default:
+		return append(append(make([]options.ExecuteScanQueryOption, 0, len(a)+len(b)), a...), b...)
+	}
+}
+
 func WithDataQueryOptions(ctx context.Context, opts []options.ExecuteDataQueryOption) context.Context {
-	return context.WithValue(ctx, ctxDataQueryOptionsKey{}, append(DataQueryOptions(ctx), opts...))
+	return context.WithValue(ctx, ctxDataQueryOptionsKey{}, mergeDataQueryOptions(dataQueryOptions(ctx), opts))
 }
 
-func DataQueryOptions(ctx context.Context) []options.ExecuteDataQueryOption {
+// DataQueryOptions returns default options merged with options from context.
+// If context contains stats callback, collecting of statistics is enabled in
+// basic mode unless options already collect them in more detailed mode.
+func DataQueryOptions(ctx context.Context, defaults []options.ExecuteDataQueryOption) []options.ExecuteDataQueryOption {
+	opts := mergeDataQueryOptions(defaults, dataQueryOptions(ctx))
+	if StatsEnabled(ctx) {
+		var d options.ExecuteDataQueryDesc
+		for _, opt := range opts {
+			opt(&d)
+		}
+		if d.CollectStats < Ydb_Table.QueryStatsCollection_STATS_COLLECTION_BASIC {
+			opts = append(opts[:len(opts):len(opts)], options.WithCollectStatsModeBasic())
+		}
+	}
+	return opts
+}
+
+func dataQueryOptions(ctx context.Context) []options.ExecuteDataQueryOption {
 	if opts, ok := ctx.Value(ctxDataQueryOptionsKey{}).([]options.ExecuteDataQueryOption); ok {
 		return opts
 	}
 	return nil
 }
 
+func mergeDataQueryOptions(a, b []options.ExecuteDataQueryOption) []options.ExecuteDataQueryOption {
+	switch {
+	case len(a) == 0:
+		return b
+	case len(b) == 0:
+		return a
+	default:
+		return append(append(make([]options.ExecuteDataQueryOption, 0, len(a)+len(b)), a...), b...)
+	}
+}
+
 // WithQueryMode returns a copy of parent context with scan query flag.
 func WithQueryMode(ctx context.Context, m mode.Type) context.Context {
 	return context.WithValue(ctx, ctxModeTypeKey{}, m)
@@ -61,3 +127,51 @@ func QueryMode(ctx context.Context) mode.Type {
 	}
 	return mode.Default
 }
+
+// WithAutoDeclare returns a copy of parent context with flag of automatic
+// declaration of query parameters.
+func WithAutoDeclare(ctx context.Context) context.Context {
+	return context.WithValue(ctx, ctxAutoDeclareKey{}, true)
+}
+
+// AutoDeclare returns true if context contains flag of automatic declaration
+// of query parameters.
+func AutoDeclare(ctx context.Context) bool {
+	v, _ := ctx.Value(ctxAutoDeclareKey{}).(bool)
+	return v
+}
+
+// WithQueryTags returns a copy of parent context with tags for queries
+// executed with it. Nested calls accumulate tags.
+func WithQueryTags(ctx context.Context, tags []string) context.Context {
+	if t := QueryTags(ctx); len(t) > 0 {
+		tags = append(append(make([]string, 0, len(t)+len(tags)), t...), tags...)
+	}
+	return context.WithValue(ctx, ctxQueryTagsKey{}, tags)
+}
+
+// QueryTags returns tags of queries from context.
+func QueryTags(ctx context.Context) []string {
+	tags, _ := ctx.Value(ctxQueryTagsKey{}).([]string)
+	return tags
+}
+
+// WithStats returns a copy of parent context with callback which receives
+// statistics of queries executed with it.
+func WithStats(ctx context.Context, f func(stats.QueryStats)) context.Context {
+	return context.WithValue(ctx, ctxStatsKey{}, f)
+}
+
+// StatsEnabled returns true if context contains stats callback.
+func StatsEnabled(ctx context.Context) bool {
+	f, _ := ctx.Value(ctxStatsKey{}).(func(stats.QueryStats))
+	return f != nil
+}
+
+// ReportStats passes statistics s of executed query to stats callback from
+// context if any.
+func ReportStats(ctx context.Context, s sdkstats.QueryStats) {
+	if f, _ := ctx.Value(ctxStatsKey{}).(func(stats.QueryStats)); f != nil {
+		f(stats.New(s))
+	}
+}
@@ -0,0 +1,131 @@
+package x
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb_Table"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/options"
+
+	"github.com/ydb-platform/ydb-go-sql/internal/stats"
+)
+
+func TestDataQueryOptionsPrecedence(t *testing.T) {
+	ctx := WithDataQueryOptions(context.Background(), []options.ExecuteDataQueryOption{
+		options.WithCollectStatsModeBasic(),
+	})
+	ctx = WithDataQueryOptions(ctx, []options.ExecuteDataQueryOption{
+		options.WithCollectStatsModeNone(),
+	})
+	defaults := []options.ExecuteDataQueryOption{
+		options.WithCollectStatsModeBasic(),
+		options.WithQueryCachePolicy(options.WithQueryCachePolicyKeepInCache()),
+	}
+	opts := DataQueryOptions(ctx, defaults)
+	if len(opts) != 4 {
+		t.Fatalf("unexpected options count: %d; want 4", len(opts))
+	}
+	var act, exp options.ExecuteDataQueryDesc
+	for _, opt := range opts {
+		opt(&act)
+	}
+	options.WithCollectStatsModeNone()(&exp)
+	if act.CollectStats != exp.CollectStats {
+		t.Fatalf("unexpected collect stats mode: %v; want %v", act.CollectStats, exp.CollectStats)
+	}
+	if act.QueryCachePolicy == nil || !act.QueryCachePolicy.KeepInCache {
+		t.Fatalf("default option not applied")
+	}
+	if len(defaults) != 2 {
+		t.Fatalf("defaults modified: %d", len(defaults))
+	}
+}
+
+func TestScanQueryOptionsPrecedence(t *testing.T) {
+	ctx := WithScanQueryOptions(context.Background(), []options.ExecuteScanQueryOption{
+		options.WithExecuteScanQueryMode(options.ExecuteScanQueryRequestModeExplain),
+	})
+	ctx = WithScanQueryOptions(ctx, []options.ExecuteScanQueryOption{
+		options.WithExecuteScanQueryMode(options.ExecuteScanQueryRequestModeExec),
+	})
+	defaults := []options.ExecuteScanQueryOption{
+		options.WithExecuteScanQueryMode(options.ExecuteScanQueryRequestModeExplain),
+	}
+	opts := ScanQueryOptions(ctx, defaults)
+	if len(opts) != 3 {
+		t.Fatalf("unexpected options count: %d; want 3", len(opts))
+	}
+	var act, exp options.ExecuteScanQueryDesc
+	for _, opt := range opts {
+		opt(&act)
+	}
+	options.WithExecuteScanQueryMode(options.ExecuteScanQueryRequestModeExec)(&exp)
+	if act.Mode != exp.Mode {
+		t.Fatalf("unexpected mode: %v; want %v", act.Mode, exp.Mode)
+	}
+	if opts := ScanQueryOptions(context.Background(), defaults); len(opts) != 1 {
+		t.Fatalf("unexpected options count without context options: %d; want 1", len(opts))
+	}
+	if len(defaults) != 1 {
+		t.Fatalf("defaults modified: %d", len(defaults))
+	}
+}
+
+func TestStatsOptions(t *testing.T) {
+	defaults := []options.ExecuteDataQueryOption{
+		options.WithCollectStatsModeNone(),
+	}
+	ctx := context.Background()
+	if opts := DataQueryOptions(ctx, defaults); len(opts) != 1 {
+		t.Fatalf("unexpected options count without stats: %d; want 1", len(opts))
+	}
+
+	var called bool
+	ctx = WithStats(ctx, func(stats.QueryStats) {
+		called = true
+	})
+	var act, exp options.ExecuteDataQueryDesc
+	for _, opt := range DataQueryOptions(ctx, defaults) {
+		opt(&act)
+	}
+	options.WithCollectStatsModeBasic()(&exp)
+	if act.CollectStats != exp.CollectStats {
+		t.Fatalf("unexpected collect stats mode: %v; want %v", act.CollectStats, exp.CollectStats)
+	}
+
+	var scan options.ExecuteScanQueryDesc
+	for _, opt := range ScanQueryOptions(ctx, nil) {
+		opt(&scan)
+	}
+	if scan.CollectStats != exp.CollectStats {
+		t.Fatalf("unexpected scan collect stats mode: %v; want %v", scan.CollectStats, exp.CollectStats)
+	}
+
+	// Explicit full mode is not downgraded to basic one.
+	full := func(d *options.ExecuteDataQueryDesc) {
+		d.CollectStats = Ydb_Table.QueryStatsCollection_STATS_COLLECTION_FULL
+	}
+	act = options.ExecuteDataQueryDesc{}
+	for _, opt := range DataQueryOptions(WithDataQueryOptions(ctx, []options.ExecuteDataQueryOption{full}), defaults) {
+		opt(&act)
+	}
+	if act.CollectStats != Ydb_Table.QueryStatsCollection_STATS_COLLECTION_FULL {
+		t.Fatalf("unexpected collect stats mode with explicit full mode: %v", act.CollectStats)
+	}
+	scan = options.ExecuteScanQueryDesc{}
+	for _, opt := range ScanQueryOptions(ctx, []options.ExecuteScanQueryOption{
+		func(d *options.ExecuteScanQueryDesc) {
+			d.CollectStats = Ydb_Table.QueryStatsCollection_STATS_COLLECTION_FULL
+		},
+	}) {
+		opt(&scan)
+	}
+	if scan.CollectStats != Ydb_Table.QueryStatsCollection_STATS_COLLECTION_FULL {
+		t.Fatalf("unexpected scan collect stats mode with explicit full mode: %v", scan.CollectStats)
+	}
+
+	ReportStats(context.Background(), nil)
+	if called {
+		t.Fatalf("callback called without stats in context")
+	}
+}
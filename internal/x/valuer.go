@@ -1,6 +1,10 @@
 package x
 
-import "github.com/ydb-platform/ydb-go-sdk/v3/table/types"
+import (
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/types"
+
+	"github.com/ydb-platform/ydb-go-sql/internal/decimal"
+)
 
 // Valuer based on types.Scanner interface and provides access to value
 type Valuer interface {
@@ -15,8 +19,8 @@ type valuer struct {
 }
 
 func (p *valuer) UnmarshalYDB(raw types.RawValue) error {
-	p.v = raw.Any()
-	return nil
+	p.v = Any(raw)
+	return raw.Err()
 }
 
 func (p *valuer) Value() interface{} {
@@ -27,3 +31,90 @@ func (p *valuer) Value() interface{} {
 func V() Valuer {
 	return &valuer{}
 }
+
+// Any converts current item of raw to Go value which is passed to database/sql
+// as column value. YDB types are converted as follows:
+//
+//	Bool                                   bool
+//	Int8, Int16, Int32, Int64              int64
+//	Uint8, Uint16, Uint32                  int64
+//	Uint64                                 uint64
+//	Float, Double                          float64
+//	Date, Datetime, Timestamp              time.Time in UTC
+//	TzDate, TzDatetime, TzTimestamp        time.Time in location of value
+//	Interval                               time.Duration
+//	String, Yson, Json, JsonDocument       []byte
+//	Utf8, DyNumber                         string
+//	UUID                                   [16]byte
+//	Decimal                                decimal.Decimal
+//	Void and empty Optional<T>             nil
+//	Optional<T>                            value of T
+//
+// Values of other types are returned as types.Value.
+func Any(raw types.RawValue) interface{} {
+	for raw.IsOptional() {
+		if raw.IsNull() {
+			return nil
+		}
+		raw.Unwrap()
+	}
+	switch raw.Type() {
+	case types.TypeBool:
+		return raw.Bool()
+	case types.TypeInt8:
+		return int64(raw.Int8())
+	case types.TypeInt16:
+		return int64(raw.Int16())
+	case types.TypeInt32:
+		return int64(raw.Int32())
+	case types.TypeInt64:
+		return raw.Int64()
+	case types.TypeUint8:
+		return int64(raw.Uint8())
+	case types.TypeUint16:
+		return int64(raw.Uint16())
+	case types.TypeUint32:
+		return int64(raw.Uint32())
+	case types.TypeUint64:
+		return raw.Uint64()
+	case types.TypeFloat:
+		return float64(raw.Float())
+	case types.TypeDouble:
+		return raw.Double()
+	case types.TypeDate:
+		return raw.Date().UTC()
+	case types.TypeDatetime:
+		return raw.Datetime().UTC()
+	case types.TypeTimestamp:
+		return raw.Timestamp().UTC()
+	case types.TypeTzDate:
+		return raw.TzDate()
+	case types.TypeTzDatetime:
+		return raw.TzDatetime()
+	case types.TypeTzTimestamp:
+		return raw.TzTimestamp()
+	case types.TypeInterval:
+		return raw.Interval()
+	case types.TypeString:
+		return raw.String()
+	case types.TypeYSON:
+		return raw.YSON()
+	case types.TypeJSON:
+		return raw.JSON()
+	case types.TypeJSONDocument:
+		return raw.JSONDocument()
+	case types.TypeUTF8:
+		return raw.UTF8()
+	case types.TypeDyNumber:
+		return raw.DyNumber()
+	case types.TypeUUID:
+		return raw.UUID()
+	}
+	if raw.IsDecimal() {
+		return decimal.FromYDB(raw.UnwrapDecimal())
+	}
+	if raw.IsNull() {
+		return nil
+	}
+	return raw.Value()
+}
@@ -0,0 +1,85 @@
+package x
+
+import (
+	"math/big"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/types"
+
+	"github.com/ydb-platform/ydb-go-sql/internal/decimal"
+)
+
+// raw is a types.RawValue stub which holds primitive value v of type t
+// wrapped into optional Optional levels.
+type raw struct {
+	types.RawValue
+
+	t        types.Type
+	v        interface{}
+	optional int
+	null     bool
+}
+
+func (r *raw) IsOptional() bool { return r.optional > 0 }
+func (r *raw) IsNull() bool     { return r.null }
+func (r *raw) Unwrap()          { r.optional-- }
+func (r *raw) Err() error       { return nil }
+func (r *raw) IsDecimal() bool  { _, ok := r.v.(types.Decimal); return ok }
+
+func (r *raw) Type() types.Type {
+	if r.optional > 0 {
+		return types.Optional(r.t)
+	}
+	return r.t
+}
+
+func (r *raw) Int8() int8                   { return r.v.(int8) }
+func (r *raw) Uint32() uint32               { return r.v.(uint32) }
+func (r *raw) Uint64() uint64               { return r.v.(uint64) }
+func (r *raw) Float() float32               { return r.v.(float32) }
+func (r *raw) Date() time.Time              { return r.v.(time.Time) }
+func (r *raw) Interval() time.Duration      { return r.v.(time.Duration) }
+func (r *raw) String() []byte               { return r.v.([]byte) }
+func (r *raw) UTF8() string                 { return r.v.(string) }
+func (r *raw) UUID() [16]byte               { return r.v.([16]byte) }
+func (r *raw) TzTimestamp() time.Time       { return r.v.(time.Time) }
+func (r *raw) UnwrapDecimal() types.Decimal { return r.v.(types.Decimal) }
+
+func TestAny(t *testing.T) {
+	var (
+		date = time.Date(2021, 11, 1, 0, 0, 0, 0, time.UTC)
+		loc  = time.FixedZone("MSK", 3*60*60)
+		tz   = time.Date(2021, 11, 1, 12, 0, 0, 0, loc)
+	)
+	for _, test := range []struct {
+		name string
+		raw  *raw
+		exp  interface{}
+	}{
+		{name: "int8", raw: &raw{t: types.TypeInt8, v: int8(-1)}, exp: int64(-1)},
+		{name: "uint32", raw: &raw{t: types.TypeUint32, v: uint32(1)}, exp: int64(1)},
+		{name: "uint64", raw: &raw{t: types.TypeUint64, v: uint64(1)}, exp: uint64(1)},
+		{name: "float", raw: &raw{t: types.TypeFloat, v: float32(0.5)}, exp: float64(0.5)},
+		{name: "date", raw: &raw{t: types.TypeDate, v: date.Local()}, exp: date},
+		{name: "tz timestamp", raw: &raw{t: types.TypeTzTimestamp, v: tz}, exp: tz},
+		{name: "interval", raw: &raw{t: types.TypeInterval, v: time.Second}, exp: time.Second},
+		{name: "string", raw: &raw{t: types.TypeString, v: []byte("foo")}, exp: []byte("foo")},
+		{name: "utf8", raw: &raw{t: types.TypeUTF8, v: "foo"}, exp: "foo"},
+		{name: "uuid", raw: &raw{t: types.TypeUUID, v: [16]byte{1}}, exp: [16]byte{1}},
+		{
+			name: "decimal",
+			raw:  &raw{t: types.DecimalType(22, 9), v: types.Decimal{Bytes: [16]byte{15: 1}, Precision: 22, Scale: 9}},
+			exp:  decimal.Decimal{Unscaled: big.NewInt(1), Precision: 22, Scale: 9},
+		},
+		{name: "optional", raw: &raw{t: types.TypeUTF8, v: "foo", optional: 2}, exp: "foo"},
+		{name: "null", raw: &raw{t: types.TypeUTF8, optional: 1, null: true}, exp: nil},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			if act := Any(test.raw); !reflect.DeepEqual(act, test.exp) {
+				t.Fatalf("unexpected value: %#v; want %#v", act, test.exp)
+			}
+		})
+	}
+}
@@ -0,0 +1,133 @@
+package x
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+)
+
+var primitiveNames = map[Ydb.Type_PrimitiveTypeId]string{
+	Ydb.Type_BOOL:          "Bool",
+	Ydb.Type_INT8:          "Int8",
+	Ydb.Type_UINT8:         "Uint8",
+	Ydb.Type_INT16:         "Int16",
+	Ydb.Type_UINT16:        "Uint16",
+	Ydb.Type_INT32:         "Int32",
+	Ydb.Type_UINT32:        "Uint32",
+	Ydb.Type_INT64:         "Int64",
+	Ydb.Type_UINT64:        "Uint64",
+	Ydb.Type_FLOAT:         "Float",
+	Ydb.Type_DOUBLE:        "Double",
+	Ydb.Type_DATE:          "Date",
+	Ydb.Type_DATETIME:      "Datetime",
+	Ydb.Type_TIMESTAMP:     "Timestamp",
+	Ydb.Type_INTERVAL:      "Interval",
+	Ydb.Type_TZ_DATE:       "TzDate",
+	Ydb.Type_TZ_DATETIME:   "TzDatetime",
+	Ydb.Type_TZ_TIMESTAMP:  "TzTimestamp",
+	Ydb.Type_STRING:        "String",
+	Ydb.Type_UTF8:          "Utf8",
+	Ydb.Type_YSON:          "Yson",
+	Ydb.Type_JSON:          "Json",
+	Ydb.Type_UUID:          "Uuid",
+	Ydb.Type_JSON_DOCUMENT: "JsonDocument",
+	Ydb.Type_DYNUMBER:      "DyNumber",
+}
+
+// TypeString returns YQL representation of t.
+func TypeString(t *Ydb.Type) string {
+	var b strings.Builder
+	writeType(&b, t)
+	return b.String()
+}
+
+// Declare returns DECLARE statement of parameter name of type t.
+func Declare(name string, t *Ydb.Type) string {
+	var b strings.Builder
+	b.WriteString("DECLARE $")
+	b.WriteString(name)
+	b.WriteString(" AS \"")
+	writeType(&b, t)
+	b.WriteString("\";\n")
+	return b.String()
+}
+
+var escaper = strings.NewReplacer("\\", "\\\\", "\"", "\\\"")
+
+// TablePathPrefix returns PRAGMA TablePathPrefix statement with prefix.
+func TablePathPrefix(prefix string) string {
+	return "PRAGMA TablePathPrefix(\"" + escaper.Replace(prefix) + "\");\n"
+}
+
+func writeType(b *strings.Builder, t *Ydb.Type) {
+	switch x := t.GetType().(type) {
+	case *Ydb.Type_TypeId:
+		if name, ok := primitiveNames[x.TypeId]; ok {
+			b.WriteString(name)
+		} else {
+			b.WriteString(x.TypeId.String())
+		}
+	case *Ydb.Type_DecimalType:
+		b.WriteString("Decimal(")
+		b.WriteString(strconv.FormatUint(uint64(x.DecimalType.Precision), 10))
+		b.WriteByte(',')
+		b.WriteString(strconv.FormatUint(uint64(x.DecimalType.Scale), 10))
+		b.WriteByte(')')
+	case *Ydb.Type_OptionalType:
+		b.WriteString("Optional<")
+		writeType(b, x.OptionalType.Item)
+		b.WriteByte('>')
+	case *Ydb.Type_ListType:
+		b.WriteString("List<")
+		writeType(b, x.ListType.Item)
+		b.WriteByte('>')
+	case *Ydb.Type_TupleType:
+		b.WriteString("Tuple<")
+		writeTypes(b, x.TupleType.Elements)
+		b.WriteByte('>')
+	case *Ydb.Type_StructType:
+		b.WriteString("Struct<")
+		writeMembers(b, x.StructType.Members)
+		b.WriteByte('>')
+	case *Ydb.Type_DictType:
+		b.WriteString("Dict<")
+		writeType(b, x.DictType.Key)
+		b.WriteByte(',')
+		writeType(b, x.DictType.Payload)
+		b.WriteByte('>')
+	case *Ydb.Type_VariantType:
+		b.WriteString("Variant<")
+		switch v := x.VariantType.Type.(type) {
+		case *Ydb.VariantType_TupleItems:
+			writeTypes(b, v.TupleItems.Elements)
+		case *Ydb.VariantType_StructItems:
+			writeMembers(b, v.StructItems.Members)
+		}
+		b.WriteByte('>')
+	case *Ydb.Type_VoidType:
+		b.WriteString("Void")
+	default:
+		b.WriteString("<unknown>")
+	}
+}
+
+func writeTypes(b *strings.Builder, ts []*Ydb.Type) {
+	for i, t := range ts {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		writeType(b, t)
+	}
+}
+
+func writeMembers(b *strings.Builder, ms []*Ydb.StructMember) {
+	for i, m := range ms {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteString(m.Name)
+		b.WriteByte(':')
+		writeType(b, m.Type)
+	}
+}
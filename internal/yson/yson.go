@@ -0,0 +1,499 @@
+// Package yson converts YSON nodes to JSON and back, so values of Yson type
+// could be decoded and encoded with encoding/json package.
+package yson
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+)
+
+// Markers of binary YSON scalars.
+const (
+	markerString = 0x01
+	markerInt64  = 0x02
+	markerDouble = 0x03
+	markerFalse  = 0x04
+	markerTrue   = 0x05
+	markerUint64 = 0x06
+)
+
+// ToJSON converts YSON node in text or binary format to JSON. Attributes of
+// nodes are dropped and entity # is converted to null. Strings which are not
+// valid UTF-8 are converted with replacement of invalid bytes. NaN and
+// infinite doubles could not be converted.
+func ToJSON(b []byte) ([]byte, error) {
+	p := parser{b: b}
+	if err := p.node(); err != nil {
+		return nil, err
+	}
+	p.skipSpace()
+	if p.i < len(p.b) {
+		return nil, p.errorf("unexpected %q after node", p.b[p.i])
+	}
+	return p.out.Bytes(), nil
+}
+
+type parser struct {
+	b   []byte
+	i   int
+	out bytes.Buffer
+}
+
+func (p *parser) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("ydb: yson: offset %d: %s", p.i, fmt.Sprintf(format, args...))
+}
+
+func (p *parser) skipSpace() {
+	for p.i < len(p.b) {
+		switch p.b[p.i] {
+		case ' ', '\t', '\n', '\r':
+			p.i++
+		default:
+			return
+		}
+	}
+}
+
+// peek skips spaces and returns next byte or 0 at the end of input.
+func (p *parser) peek() byte {
+	p.skipSpace()
+	if p.i == len(p.b) {
+		return 0
+	}
+	return p.b[p.i]
+}
+
+func (p *parser) expect(c byte) error {
+	if p.peek() != c {
+		return p.errorf("expected %q", c)
+	}
+	p.i++
+	return nil
+}
+
+func (p *parser) node() error {
+	if p.peek() == '<' {
+		if err := p.attributes(); err != nil {
+			return err
+		}
+	}
+	c := p.peek()
+	switch {
+	case c == '{':
+		return p.mapping()
+	case c == '[':
+		return p.list()
+	case c == '#':
+		p.i++
+		p.out.WriteString("null")
+		return nil
+	case c == '%':
+		return p.literal()
+	case c == '-' || c == '+' || isDigit(c):
+		return p.number()
+	case c == markerInt64:
+		p.i++
+		v, err := p.varint()
+		if err != nil {
+			return err
+		}
+		p.out.WriteString(strconv.FormatInt(v, 10))
+		return nil
+	case c == markerUint64:
+		p.i++
+		v, err := p.uvarint()
+		if err != nil {
+			return err
+		}
+		p.out.WriteString(strconv.FormatUint(v, 10))
+		return nil
+	case c == markerDouble:
+		p.i++
+		if len(p.b)-p.i < 8 {
+			return p.errorf("unexpected end of double")
+		}
+		f := math.Float64frombits(binary.LittleEndian.Uint64(p.b[p.i:]))
+		p.i += 8
+		return p.float(f)
+	case c == markerFalse:
+		p.i++
+		p.out.WriteString("false")
+		return nil
+	case c == markerTrue:
+		p.i++
+		p.out.WriteString("true")
+		return nil
+	case c == 0 && p.i == len(p.b):
+		return p.errorf("unexpected end of input")
+	default:
+		s, err := p.str()
+		if err != nil {
+			return err
+		}
+		p.writeString(s)
+		return nil
+	}
+}
+
+// attributes skips attributes of node.
+func (p *parser) attributes() error {
+	p.i++
+	defer p.out.Truncate(p.out.Len())
+	return p.items('>', func() error {
+		if _, err := p.str(); err != nil {
+			return err
+		}
+		if err := p.expect('='); err != nil {
+			return err
+		}
+		return p.node()
+	})
+}
+
+func (p *parser) mapping() error {
+	p.i++
+	p.out.WriteByte('{')
+	n := 0
+	err := p.items('}', func() error {
+		if n > 0 {
+			p.out.WriteByte(',')
+		}
+		n++
+		k, err := p.str()
+		if err != nil {
+			return err
+		}
+		p.writeString(k)
+		if err = p.expect('='); err != nil {
+			return err
+		}
+		p.out.WriteByte(':')
+		return p.node()
+	})
+	p.out.WriteByte('}')
+	return err
+}
+
+func (p *parser) list() error {
+	p.i++
+	p.out.WriteByte('[')
+	n := 0
+	err := p.items(']', func() error {
+		if n > 0 {
+			p.out.WriteByte(',')
+		}
+		n++
+		return p.node()
+	})
+	p.out.WriteByte(']')
+	return err
+}
+
+// items calls item for each item of container until closing byte end. Items
+// are separated with semicolon, trailing semicolon is allowed.
+func (p *parser) items(end byte, item func() error) error {
+	for {
+		if p.peek() == end {
+			p.i++
+			return nil
+		}
+		if err := item(); err != nil {
+			return err
+		}
+		switch p.peek() {
+		case ';':
+			p.i++
+		case end:
+		default:
+			return p.errorf("expected ';' or %q", end)
+		}
+	}
+}
+
+func (p *parser) literal() error {
+	for _, l := range []struct {
+		text, json string
+	}{
+		{"%true", "true"},
+		{"%false", "false"},
+	} {
+		if bytes.HasPrefix(p.b[p.i:], []byte(l.text)) {
+			p.i += len(l.text)
+			p.out.WriteString(l.json)
+			return nil
+		}
+	}
+	return p.errorf("unsupported literal")
+}
+
+func (p *parser) number() error {
+	start := p.i
+	for p.i < len(p.b) && isNumber(p.b[p.i]) {
+		p.i++
+	}
+	s := string(p.b[start:p.i])
+	if p.i < len(p.b) && p.b[p.i] == 'u' {
+		p.i++
+		v, err := strconv.ParseUint(s, 10, 64)
+		if err != nil {
+			return p.errorf("invalid uint64 %q", s)
+		}
+		p.out.WriteString(strconv.FormatUint(v, 10))
+		return nil
+	}
+	if v, err := strconv.ParseInt(s, 10, 64); err == nil {
+		p.out.WriteString(strconv.FormatInt(v, 10))
+		return nil
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return p.errorf("invalid number %q", s)
+	}
+	return p.float(f)
+}
+
+func (p *parser) float(f float64) error {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return p.errorf("%v could not be converted to json", f)
+	}
+	p.out.WriteString(strconv.FormatFloat(f, 'g', -1, 64))
+	return nil
+}
+
+// str parses quoted, unquoted or binary string.
+func (p *parser) str() (string, error) {
+	c := p.peek()
+	switch {
+	case c == '"':
+		return p.quoted()
+	case c == markerString:
+		p.i++
+		n, err := p.varint()
+		if err != nil {
+			return "", err
+		}
+		if n < 0 || int64(len(p.b)-p.i) < n {
+			return "", p.errorf("invalid length of string %d", n)
+		}
+		s := string(p.b[p.i : p.i+int(n)])
+		p.i += int(n)
+		return s, nil
+	case isIdentStart(c):
+		start := p.i
+		for p.i < len(p.b) && isIdent(p.b[p.i]) {
+			p.i++
+		}
+		return string(p.b[start:p.i]), nil
+	default:
+		return "", p.errorf("unexpected %q", c)
+	}
+}
+
+// quoted parses string in double quotes with C escape sequences.
+func (p *parser) quoted() (string, error) {
+	p.i++
+	var s []byte
+	for p.i < len(p.b) {
+		c := p.b[p.i]
+		p.i++
+		switch c {
+		case '"':
+			return string(s), nil
+		case '\\':
+			c, err := p.escape()
+			if err != nil {
+				return "", err
+			}
+			s = append(s, c)
+		default:
+			s = append(s, c)
+		}
+	}
+	return "", p.errorf("unterminated string")
+}
+
+func (p *parser) escape() (byte, error) {
+	if p.i == len(p.b) {
+		return 0, p.errorf("unterminated escape sequence")
+	}
+	c := p.b[p.i]
+	p.i++
+	switch c {
+	case 'a':
+		return '\a', nil
+	case 'b':
+		return '\b', nil
+	case 'f':
+		return '\f', nil
+	case 'n':
+		return '\n', nil
+	case 'r':
+		return '\r', nil
+	case 't':
+		return '\t', nil
+	case 'v':
+		return '\v', nil
+	case 'x':
+		if len(p.b)-p.i < 2 {
+			return 0, p.errorf("invalid hex escape sequence")
+		}
+		v, err := strconv.ParseUint(string(p.b[p.i:p.i+2]), 16, 8)
+		if err != nil {
+			return 0, p.errorf("invalid hex escape sequence")
+		}
+		p.i += 2
+		return byte(v), nil
+	}
+	if '0' <= c && c <= '7' {
+		v := uint(c - '0')
+		for n := 1; n < 3 && p.i < len(p.b) && '0' <= p.b[p.i] && p.b[p.i] <= '7'; n++ {
+			v = v*8 + uint(p.b[p.i]-'0')
+			p.i++
+		}
+		if v > math.MaxUint8 {
+			return 0, p.errorf("invalid octal escape sequence")
+		}
+		return byte(v), nil
+	}
+	return c, nil
+}
+
+// varint reads zigzag encoded signed varint.
+func (p *parser) varint() (int64, error) {
+	v, n := binary.Varint(p.b[p.i:])
+	if n <= 0 {
+		return 0, p.errorf("invalid varint")
+	}
+	p.i += n
+	return v, nil
+}
+
+func (p *parser) uvarint() (uint64, error) {
+	v, n := binary.Uvarint(p.b[p.i:])
+	if n <= 0 {
+		return 0, p.errorf("invalid varint")
+	}
+	p.i += n
+	return v, nil
+}
+
+func (p *parser) writeString(s string) {
+	b, _ := json.Marshal(s)
+	p.out.Write(b)
+}
+
+func isDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
+
+func isNumber(c byte) bool {
+	return isDigit(c) || c == '-' || c == '+' || c == '.' || c == 'e' || c == 'E'
+}
+
+func isIdentStart(c byte) bool {
+	return c == '_' || 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z'
+}
+
+func isIdent(c byte) bool {
+	return isIdentStart(c) || isDigit(c) || c == '-' || c == '.'
+}
+
+// FromJSON converts JSON document to YSON node in text format. Integer
+// numbers are converted to int64 or to uint64 if they exceed int64, other
+// numbers to doubles.
+func FromJSON(b []byte) ([]byte, error) {
+	d := json.NewDecoder(bytes.NewReader(b))
+	d.UseNumber()
+	var out bytes.Buffer
+	if err := fromJSON(d, &out); err != nil {
+		return nil, err
+	}
+	if d.More() {
+		return nil, fmt.Errorf("ydb: yson: unexpected data after json value")
+	}
+	return out.Bytes(), nil
+}
+
+func fromJSON(d *json.Decoder, out *bytes.Buffer) error {
+	t, err := d.Token()
+	if err != nil {
+		return fmt.Errorf("ydb: yson: %w", err)
+	}
+	switch x := t.(type) {
+	case json.Delim:
+		end, sep := byte(']'), byte(0)
+		if x == '{' {
+			end, sep = '}', '='
+		}
+		out.WriteByte(byte(x))
+		for d.More() {
+			if sep != 0 {
+				k, err := d.Token()
+				if err != nil {
+					return fmt.Errorf("ydb: yson: %w", err)
+				}
+				quote(out, k.(string))
+				out.WriteByte(sep)
+			}
+			if err = fromJSON(d, out); err != nil {
+				return err
+			}
+			out.WriteByte(';')
+		}
+		if _, err = d.Token(); err != nil {
+			return fmt.Errorf("ydb: yson: %w", err)
+		}
+		out.WriteByte(end)
+	case string:
+		quote(out, x)
+	case json.Number:
+		s := x.String()
+		if _, err := strconv.ParseInt(s, 10, 64); err == nil {
+			out.WriteString(s)
+		} else if _, err := strconv.ParseUint(s, 10, 64); err == nil {
+			out.WriteString(s)
+			out.WriteByte('u')
+		} else {
+			f, err := x.Float64()
+			if err != nil {
+				return fmt.Errorf("ydb: yson: %w", err)
+			}
+			s = strconv.FormatFloat(f, 'g', -1, 64)
+			if _, err := strconv.ParseInt(s, 10, 64); err == nil {
+				// Keep type of value: 1e2 is a double, not an integer.
+				s += "."
+			}
+			out.WriteString(s)
+		}
+	case bool:
+		if x {
+			out.WriteString("%true")
+		} else {
+			out.WriteString("%false")
+		}
+	case nil:
+		out.WriteByte('#')
+	}
+	return nil
+}
+
+// quote writes s as YSON string in double quotes.
+func quote(out *bytes.Buffer, s string) {
+	out.WriteByte('"')
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case c == '"' || c == '\\':
+			out.WriteByte('\\')
+			out.WriteByte(c)
+		case c < 0x20 || c == 0x7f:
+			fmt.Fprintf(out, "\\x%02x", c)
+		default:
+			out.WriteByte(c)
+		}
+	}
+	out.WriteByte('"')
+}
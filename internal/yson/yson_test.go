@@ -0,0 +1,77 @@
+package yson
+
+import (
+	"testing"
+)
+
+func TestToJSON(t *testing.T) {
+	for _, test := range []struct {
+		yson string
+		json string
+		err  bool
+	}{
+		{yson: `{id=1;title="IT Crowd";tags=[comedy;"sit-com";];rating=8.5;}`, json: `{"id":1,"title":"IT Crowd","tags":["comedy","sit-com"],"rating":8.5}`},
+		{yson: ` < type = series > { ok = %true ; bad = %false ; none = # } `, json: `{"ok":true,"bad":false,"none":null}`},
+		{yson: `[42u; -7; 1e3; "a\"b\\c\n\x41\101"]`, json: `[42,-7,1000,"a\"b\\c\nAA"]`},
+		{yson: "\x01\x06abc", json: `"abc"`},
+		{yson: "[\x02\x0d;\x06\x80\x01;\x03\x00\x00\x00\x00\x00\x00\xf8\x3f;\x04;\x05]", json: `[-7,128,1.5,false,true]`},
+		{yson: `{}`, json: `{}`},
+		{yson: `{a=1`, err: true},
+		{yson: `{a:1}`, err: true},
+		{yson: `%nan`, err: true},
+		{yson: `1 2`, err: true},
+		{yson: ``, err: true},
+	} {
+		t.Run(test.yson, func(t *testing.T) {
+			act, err := ToJSON([]byte(test.yson))
+			if test.err {
+				if err == nil {
+					t.Fatalf("expected error, got %s", act)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if string(act) != test.json {
+				t.Fatalf("unexpected json: %s; want %s", act, test.json)
+			}
+		})
+	}
+}
+
+func TestFromJSON(t *testing.T) {
+	for _, test := range []struct {
+		json string
+		yson string
+		back string
+	}{
+		{
+			json: `{"id":1,"title":"IT \"Crowd\"\n","tags":["comedy"],"rating":8.5}`,
+			yson: `{"id"=1;"title"="IT \"Crowd\"\x0a";"tags"=["comedy";];"rating"=8.5;}`,
+			back: `{"id":1,"title":"IT \"Crowd\"\n","tags":["comedy"],"rating":8.5}`,
+		},
+		{
+			json: `[true,false,null,18446744073709551615,1e2]`,
+			yson: `[%true;%false;#;18446744073709551615u;100.;]`,
+			back: `[true,false,null,18446744073709551615,100]`,
+		},
+	} {
+		t.Run(test.json, func(t *testing.T) {
+			act, err := FromJSON([]byte(test.json))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if string(act) != test.yson {
+				t.Fatalf("unexpected yson: %s; want %s", act, test.yson)
+			}
+			back, err := ToJSON(act)
+			if err != nil {
+				t.Fatalf("unexpected error of round trip: %v", err)
+			}
+			if string(back) != test.back {
+				t.Fatalf("unexpected json of round trip: %s; want %s", back, test.back)
+			}
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package ydb
+
+import (
+	"github.com/ydb-platform/ydb-go-sql/internal/scan"
+)
+
+// JSONValue wraps Go value for passing it as Json or JsonDocument query
+// parameter and for scanning Json or JsonDocument column into it with
+// encoding/json package.
+type JSONValue = scan.JSON
+
+// YSONValue wraps Go value for passing it as Yson query parameter and for
+// scanning Yson column into it. Values are converted between YSON and JSON,
+// so they are marshaled with encoding/json package and json tags.
+type YSONValue = scan.YSON
+
+// JSON wraps v for passing as Optional<Json> query parameter or for scanning
+// Json and JsonDocument columns. Nil v is passed as NULL:
+//
+//	_, err = db.ExecContext(ctx, query, sql.Named("info", ydb.JSON(info)))
+//
+//	var info SeriesInfo
+//	err = row.Scan(ydb.JSON(&info))
+func JSON(v interface{}) *JSONValue {
+	return &JSONValue{V: v}
+}
+
+// JSONDocument wraps v for passing as Optional<JsonDocument> query parameter
+// or for scanning Json and JsonDocument columns.
+func JSONDocument(v interface{}) *JSONValue {
+	return &JSONValue{V: v, Document: true}
+}
+
+// YSON wraps v for passing as Optional<Yson> query parameter or for scanning
+// Yson columns in text or binary format. Attributes of YSON nodes are dropped on
+// scanning:
+//
+//	var attrs map[string]interface{}
+//	err = row.Scan(ydb.YSON(&attrs))
+func YSON(v interface{}) *YSONValue {
+	return &YSONValue{V: v}
+}
@@ -0,0 +1,65 @@
+package ydb
+
+import (
+	"regexp"
+	"time"
+
+	"github.com/ydb-platform/ydb-go-sql/internal/connector"
+	"github.com/ydb-platform/ydb-go-sql/internal/querylog"
+)
+
+type (
+	// Logger writes structured log records. Args are alternating keys and
+	// values as in log/slog, so *slog.Logger is adapted with LoggerFunc:
+	//
+	//	ydb.LoggerFunc(func(ctx context.Context, level ydb.LogLevel, msg string, args ...interface{}) {
+	//		logger.Log(ctx, slog.Level(level), msg, args...)
+	//	})
+	Logger     = querylog.Logger
+	LoggerFunc = querylog.LoggerFunc
+	LogLevel   = querylog.Level
+	LogOption  = querylog.Option
+)
+
+// Levels of log records. Values are the same as of log/slog levels.
+const (
+	LogLevelDebug = querylog.LevelDebug
+	LogLevelInfo  = querylog.LevelInfo
+	LogLevelWarn  = querylog.LevelWarn
+	LogLevelError = querylog.LevelError
+)
+
+// WithQueryLog enables logging of executed queries, statements and finished
+// transactions into l. Record of query contains query text, mode, args,
+// transaction id, duration, number of read rows and error. Failed queries are
+// logged with LogLevelError.
+func WithQueryLog(l Logger, opts ...LogOption) connector.Option {
+	return connector.WithLog(l, opts...)
+}
+
+// WithLogLevel sets level of records of queries which are neither failed nor
+// slow. Default level is LogLevelInfo.
+func WithLogLevel(level LogLevel) LogOption {
+	return querylog.WithLevel(level)
+}
+
+// WithLogSlowThreshold sets duration since which query is considered slow.
+// Slow queries are logged with LogLevelWarn.
+func WithLogSlowThreshold(threshold time.Duration) LogOption {
+	return querylog.WithSlowThreshold(threshold)
+}
+
+// WithLogExplain enables attaching plan to records of slow data and scan
+// queries which succeeded. Query is explained on the same session before
+// record is logged, so it delays return of slow query.
+func WithLogExplain() LogOption {
+	return querylog.WithExplain()
+}
+
+// WithLogRedactParams sets pattern of parameter names which values are not
+// logged. Names are matched without $ prefix, e.g.
+//
+//	ydb.WithLogRedactParams(regexp.MustCompile(`(?i)password|token`))
+func WithLogRedactParams(pattern *regexp.Regexp) LogOption {
+	return querylog.WithRedact(pattern)
+}
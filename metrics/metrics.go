@@ -0,0 +1,214 @@
+// Package metrics contains collector of database/sql driver metrics built on
+// hooks of github.com/ydb-platform/ydb-go-sql/trace package.
+//
+// Collected metrics are:
+//
+//	ydb_sql_queries_total{mode,status}          counter of executed queries
+//	ydb_sql_query_duration_seconds{mode}        histogram of query latencies
+//	ydb_sql_transactions_total{outcome}         counter of finished transactions
+//	ydb_sql_transaction_retries_total           counter of retried transactions
+//	ydb_sql_errors_total{operation,kind}        counter of errors
+//	ydb_sql_sessions_open                       gauge of open sessions
+//
+// Label mode is a query mode: data_query, scan_query, scheme_query or
+// explain_query. Label status is ok or kind of error. Label kind is a class of
+// error: bad_conn (session is invalidated and connection is discarded),
+// operation (error returned by server), transport (error of network or grpc)
+// or other. Label outcome is one of begin_failed, committed, commit_failed,
+// rolled_back and rollback_failed.
+package metrics
+
+import (
+	"database/sql/driver"
+	"errors"
+	"time"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3"
+
+	internal "github.com/ydb-platform/ydb-go-sql/internal/errors"
+	"github.com/ydb-platform/ydb-go-sql/internal/retry"
+	"github.com/ydb-platform/ydb-go-sql/trace"
+)
+
+type (
+	Counter interface {
+		Inc()
+	}
+	Gauge interface {
+		Inc()
+		Dec()
+	}
+	Histogram interface {
+		Observe(float64)
+	}
+
+	// CounterVec, GaugeVec and HistogramVec return metric with given label
+	// values which are passed in order of label names.
+	CounterVec interface {
+		With(labelValues ...string) Counter
+	}
+	GaugeVec interface {
+		With(labelValues ...string) Gauge
+	}
+	HistogramVec interface {
+		With(labelValues ...string) Histogram
+	}
+
+	// Registry creates metrics with given names, help strings and label
+	// names. Implementation could be backed by prometheus.Registerer or other
+	// metrics library. Metrics must be safe for concurrent use.
+	Registry interface {
+		CounterVec(name, help string, labelNames ...string) CounterVec
+		GaugeVec(name, help string, labelNames ...string) GaugeVec
+		HistogramVec(name, help string, buckets []float64, labelNames ...string) HistogramVec
+	}
+)
+
+// DefaultBuckets are buckets of query duration histogram in seconds.
+var DefaultBuckets = []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+
+const statusOK = "ok"
+
+// Trace returns trace.Trace which collects metrics into r. Metrics are
+// created once on Trace call.
+func Trace(r Registry) trace.Trace {
+	var (
+		queries = r.CounterVec(
+			"ydb_sql_queries_total",
+			"Number of executed queries.",
+			"mode", "status",
+		)
+		durations = r.HistogramVec(
+			"ydb_sql_query_duration_seconds",
+			"Latency of query execution in seconds.",
+			DefaultBuckets,
+			"mode",
+		)
+		transactions = r.CounterVec(
+			"ydb_sql_transactions_total",
+			"Number of finished transactions.",
+			"outcome",
+		)
+		retries = r.CounterVec(
+			"ydb_sql_transaction_retries_total",
+			"Number of transactions retried by retry helpers.",
+		).With()
+		errs = r.CounterVec(
+			"ydb_sql_errors_total",
+			"Number of errors.",
+			"operation", "kind",
+		)
+		sessions = r.GaugeVec(
+			"ydb_sql_sessions_open",
+			"Number of open sessions.",
+		).With()
+	)
+	fail := func(operation string, err error) {
+		if err != nil {
+			errs.With(operation, errorKind(err)).Inc()
+		}
+	}
+	query := func(operation, mode string) func(error) {
+		start := time.Now()
+		return func(err error) {
+			status := statusOK
+			if err != nil {
+				status = errorKind(err)
+			}
+			queries.With(mode, status).Inc()
+			durations.With(mode).Observe(time.Since(start).Seconds())
+			fail(operation, err)
+		}
+	}
+	return trace.Trace{
+		OnConnect: func(trace.ConnectStartInfo) func(trace.ConnectDoneInfo) {
+			return func(info trace.ConnectDoneInfo) {
+				if info.Error == nil {
+					sessions.Inc()
+				}
+				fail("connect", info.Error)
+			}
+		},
+		OnClose: func(trace.CloseStartInfo) func(trace.CloseDoneInfo) {
+			return func(info trace.CloseDoneInfo) {
+				sessions.Dec()
+				fail("close", info.Error)
+			}
+		},
+		OnPing: func(trace.PingStartInfo) func(trace.PingDoneInfo) {
+			return func(info trace.PingDoneInfo) {
+				fail("ping", info.Error)
+			}
+		},
+		OnPrepare: func(trace.PrepareStartInfo) func(trace.PrepareDoneInfo) {
+			return func(info trace.PrepareDoneInfo) {
+				fail("prepare", info.Error)
+			}
+		},
+		OnExec: func(info trace.ExecStartInfo) func(trace.ExecDoneInfo) {
+			onDone := query("exec", info.Mode)
+			return func(info trace.ExecDoneInfo) {
+				onDone(info.Error)
+			}
+		},
+		OnQuery: func(info trace.QueryStartInfo) func(trace.QueryDoneInfo) {
+			onDone := query("query", info.Mode)
+			return func(info trace.QueryDoneInfo) {
+				onDone(info.Error)
+			}
+		},
+		OnBegin: func(info trace.BeginStartInfo) func(trace.BeginDoneInfo) {
+			// Transactions of TxDoer are begun within context of attempt, so
+			// each begin on attempt after the first one is a retry.
+			if retry.Attempt(*info.Context) > 1 {
+				retries.Inc()
+			}
+			return func(info trace.BeginDoneInfo) {
+				if info.Error != nil {
+					transactions.With("begin_failed").Inc()
+				}
+				fail("begin", info.Error)
+			}
+		},
+		OnCommit: func(trace.CommitStartInfo) func(trace.CommitDoneInfo) {
+			return func(info trace.CommitDoneInfo) {
+				if info.Error != nil {
+					transactions.With("commit_failed").Inc()
+				} else {
+					transactions.With("committed").Inc()
+				}
+				fail("commit", info.Error)
+			}
+		},
+		OnRollback: func(trace.RollbackStartInfo) func(trace.RollbackDoneInfo) {
+			return func(info trace.RollbackDoneInfo) {
+				if errors.Is(info.Error, internal.ErrTxCommitted) || errors.Is(info.Error, internal.ErrTxRolledBack) {
+					// Transaction has been finished already.
+					return
+				}
+				if info.Error != nil {
+					transactions.With("rollback_failed").Inc()
+				} else {
+					transactions.With("rolled_back").Inc()
+				}
+				fail("rollback", info.Error)
+			}
+		},
+	}
+}
+
+// errorKind classifies errors returned by driver. Errors which invalidate
+// session are mapped to driver.ErrBadConn by driver, so bad_conn is checked
+// first.
+func errorKind(err error) string {
+	switch {
+	case errors.Is(err, driver.ErrBadConn):
+		return "bad_conn"
+	case ydb.IsOperationError(err):
+		return "operation"
+	case ydb.IsTransportError(err):
+		return "transport"
+	default:
+		return "other"
+	}
+}
@@ -0,0 +1,126 @@
+package metrics
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	internal "github.com/ydb-platform/ydb-go-sql/internal/errors"
+	"github.com/ydb-platform/ydb-go-sql/internal/retry"
+	"github.com/ydb-platform/ydb-go-sql/trace"
+)
+
+// registry stores values of metrics by name and label values joined with
+// comma, e.g. "ydb_sql_queries_total{data_query,ok}".
+type registry struct {
+	mu     sync.Mutex
+	values map[string]float64
+}
+
+type metric struct {
+	r   *registry
+	key string
+}
+
+func (m metric) add(v float64) {
+	m.r.mu.Lock()
+	defer m.r.mu.Unlock()
+	m.r.values[m.key] += v
+}
+
+func (m metric) Inc()              { m.add(1) }
+func (m metric) Dec()              { m.add(-1) }
+func (m metric) Observe(v float64) { m.add(1) }
+
+type vec struct {
+	r    *registry
+	name string
+}
+
+func (v vec) metric(labelValues []string) metric {
+	key := v.name
+	if len(labelValues) > 0 {
+		key += "{" + strings.Join(labelValues, ",") + "}"
+	}
+	return metric{r: v.r, key: key}
+}
+
+type (
+	counterVec   struct{ vec }
+	gaugeVec     struct{ vec }
+	histogramVec struct{ vec }
+)
+
+func (v counterVec) With(labelValues ...string) Counter     { return v.metric(labelValues) }
+func (v gaugeVec) With(labelValues ...string) Gauge         { return v.metric(labelValues) }
+func (v histogramVec) With(labelValues ...string) Histogram { return v.metric(labelValues) }
+
+func (r *registry) CounterVec(name, _ string, _ ...string) CounterVec {
+	return counterVec{vec{r, name}}
+}
+
+func (r *registry) GaugeVec(name, _ string, _ ...string) GaugeVec {
+	return gaugeVec{vec{r, name}}
+}
+
+func (r *registry) HistogramVec(name, _ string, _ []float64, _ ...string) HistogramVec {
+	return histogramVec{vec{r, name}}
+}
+
+func TestTrace(t *testing.T) {
+	r := &registry{values: make(map[string]float64)}
+	tr := Trace(r)
+
+	_ = retry.Retry(context.Background(), func(ctx context.Context) error {
+		trace.TraceOnConnect(ctx, tr, &ctx)(nil)
+		trace.TraceOnBegin(ctx, tr, &ctx, driver.IsolationLevel(0), false)("tx", nil)
+		trace.TraceOnQuery(ctx, tr, &ctx, "SELECT 1", "data_query", 0, "tx", false)(nil)
+		if retry.Attempt(ctx) == 1 {
+			trace.TraceOnExec(ctx, tr, &ctx, "UPSERT", "data_query", 1, "tx", false)(driver.ErrBadConn)
+			trace.TraceOnRollback(ctx, tr, &ctx, "tx")(nil)
+			trace.TraceOnClose(ctx, tr, &ctx)(nil)
+			return driver.ErrBadConn
+		}
+		trace.TraceOnCommit(ctx, tr, &ctx, "tx")(nil)
+		trace.TraceOnRollback(ctx, tr, &ctx, "tx")(internal.ErrTxCommitted)
+		return nil
+	}, retry.WithIdempotent(true))
+	ctx := context.Background()
+	trace.TraceOnQuery(ctx, tr, &ctx, "SELECT 1", "scan_query", 0, "", false)(errors.New("fail"))
+
+	exp := map[string]float64{
+		"ydb_sql_queries_total{data_query,ok}":       2,
+		"ydb_sql_queries_total{data_query,bad_conn}": 1,
+		"ydb_sql_queries_total{scan_query,other}":    1,
+		"ydb_sql_query_duration_seconds{data_query}": 3,
+		"ydb_sql_query_duration_seconds{scan_query}": 1,
+		"ydb_sql_transactions_total{rolled_back}":    1,
+		"ydb_sql_transactions_total{committed}":      1,
+		"ydb_sql_transaction_retries_total":          1,
+		"ydb_sql_errors_total{exec,bad_conn}":        1,
+		"ydb_sql_errors_total{query,other}":          1,
+		"ydb_sql_sessions_open":                      1,
+	}
+	if !reflect.DeepEqual(r.values, exp) {
+		t.Fatalf("unexpected metrics: %v; want %v", r.values, exp)
+	}
+}
+
+func TestErrorKind(t *testing.T) {
+	for _, test := range []struct {
+		err  error
+		kind string
+	}{
+		{driver.ErrBadConn, "bad_conn"},
+		{internal.ErrTxBroken, "other"},
+		{context.Canceled, "other"},
+	} {
+		if kind := errorKind(test.err); kind != test.kind {
+			t.Errorf("unexpected kind of %v: %q; want %q", test.err, kind, test.kind)
+		}
+	}
+}
@@ -0,0 +1,14 @@
+module github.com/ydb-platform/ydb-go-sql/otel
+
+go 1.16
+
+require (
+	github.com/ydb-platform/ydb-go-sdk/v3 v3.5.2
+	github.com/ydb-platform/ydb-go-sql v0.0.0
+	go.opentelemetry.io/otel v1.7.0
+	go.opentelemetry.io/otel/sdk v1.7.0
+	go.opentelemetry.io/otel/trace v1.7.0
+	golang.org/x/sys v0.10.0 // indirect
+)
+
+replace github.com/ydb-platform/ydb-go-sql => ../
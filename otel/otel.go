@@ -0,0 +1,207 @@
+// Package otel contains OpenTelemetry instrumentation of ydb-go-sql driver.
+//
+// Trace starts spans for queries and transactions executed via database/sql
+// and replaces context of operation with context of span, so spans started by
+// Table within ydb-go-sdk become children of them:
+//
+//	tracer := otel.Tracer("ydb")
+//	connector := ydb.Connector(
+//		ydb.WithTraceSQL(ydbotel.Trace(tracer)),
+//		ydb.WithTraceTable(ydbotel.Table(tracer)),
+//		...
+//	)
+package otel
+
+import (
+	"context"
+	"database/sql"
+
+	sdktrace "github.com/ydb-platform/ydb-go-sdk/v3/trace"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	oteltrace "go.opentelemetry.io/otel/trace"
+
+	"github.com/ydb-platform/ydb-go-sql"
+	"github.com/ydb-platform/ydb-go-sql/trace"
+)
+
+// Attribute keys of spans in addition to db.system and db.statement of
+// OpenTelemetry semantic conventions.
+const (
+	KeyQueryMode    = attribute.Key("ydb.query.mode")
+	KeyQueryArgs    = attribute.Key("ydb.query.args")
+	KeyPrepared     = attribute.Key("ydb.query.prepared")
+	KeyTxID         = attribute.Key("ydb.tx.id")
+	KeyTxIsolation  = attribute.Key("ydb.tx.isolation")
+	KeyTxReadOnly   = attribute.Key("ydb.tx.read_only")
+	KeyRetryAttempt = attribute.Key("ydb.retry.attempt")
+	KeySessionID    = attribute.Key("ydb.session.id")
+	keyDBSystem     = attribute.Key("db.system")
+	keyDBStatement  = attribute.Key("db.statement")
+	dbSystem        = "ydb"
+)
+
+// Trace returns trace.Trace which starts span of tracer for connect, ping,
+// prepare, exec, query, begin, commit and rollback operations. Rows and
+// session reset events are not traced.
+func Trace(tracer oteltrace.Tracer) trace.Trace {
+	return trace.Trace{
+		OnConnect: func(info trace.ConnectStartInfo) func(trace.ConnectDoneInfo) {
+			end := start(tracer, info.Context, "ydb.connect")
+			return func(info trace.ConnectDoneInfo) {
+				end(info.Error)
+			}
+		},
+		OnPing: func(info trace.PingStartInfo) func(trace.PingDoneInfo) {
+			end := start(tracer, info.Context, "ydb.ping")
+			return func(info trace.PingDoneInfo) {
+				end(info.Error)
+			}
+		},
+		OnPrepare: func(info trace.PrepareStartInfo) func(trace.PrepareDoneInfo) {
+			end := start(tracer, info.Context, "ydb.prepare",
+				keyDBStatement.String(info.Query),
+				KeyTxID.String(info.TxID),
+			)
+			return func(info trace.PrepareDoneInfo) {
+				end(info.Error)
+			}
+		},
+		OnExec: func(info trace.ExecStartInfo) func(trace.ExecDoneInfo) {
+			end := start(tracer, info.Context, "ydb.exec",
+				keyDBStatement.String(info.Query),
+				KeyQueryMode.String(info.Mode),
+				KeyQueryArgs.Int(info.ArgsCount),
+				KeyTxID.String(info.TxID),
+				KeyPrepared.Bool(info.Prepared),
+			)
+			return func(info trace.ExecDoneInfo) {
+				end(info.Error)
+			}
+		},
+		OnQuery: func(info trace.QueryStartInfo) func(trace.QueryDoneInfo) {
+			end := start(tracer, info.Context, "ydb.query",
+				keyDBStatement.String(info.Query),
+				KeyQueryMode.String(info.Mode),
+				KeyQueryArgs.Int(info.ArgsCount),
+				KeyTxID.String(info.TxID),
+				KeyPrepared.Bool(info.Prepared),
+			)
+			return func(info trace.QueryDoneInfo) {
+				end(info.Error)
+			}
+		},
+		OnBegin: func(info trace.BeginStartInfo) func(trace.BeginDoneInfo) {
+			span := startSpan(tracer, info.Context, "ydb.begin",
+				KeyTxIsolation.String(sql.IsolationLevel(info.Isolation).String()),
+				KeyTxReadOnly.Bool(info.ReadOnly),
+			)
+			return func(info trace.BeginDoneInfo) {
+				span.SetAttributes(KeyTxID.String(info.TxID))
+				finish(span, info.Error)
+			}
+		},
+		OnCommit: func(info trace.CommitStartInfo) func(trace.CommitDoneInfo) {
+			end := start(tracer, info.Context, "ydb.commit",
+				KeyTxID.String(info.TxID),
+			)
+			return func(info trace.CommitDoneInfo) {
+				end(info.Error)
+			}
+		},
+		OnRollback: func(info trace.RollbackStartInfo) func(trace.RollbackDoneInfo) {
+			end := start(tracer, info.Context, "ydb.rollback",
+				KeyTxID.String(info.TxID),
+			)
+			return func(info trace.RollbackDoneInfo) {
+				end(info.Error)
+			}
+		},
+	}
+}
+
+// Table returns trace.Table of ydb-go-sdk which starts span of tracer for
+// execution of queries and transaction calls on session. Spans are children
+// of spans started by Trace when operation is done via database/sql.
+func Table(tracer oteltrace.Tracer) sdktrace.Table {
+	return sdktrace.Table{
+		OnSessionQueryPrepare: func(info sdktrace.SessionQueryPrepareStartInfo) func(sdktrace.PrepareDataQueryDoneInfo) {
+			end := start(tracer, info.Context, "ydb.session.prepare",
+				KeySessionID.String(info.Session.ID()),
+			)
+			return func(info sdktrace.PrepareDataQueryDoneInfo) {
+				end(info.Error)
+			}
+		},
+		OnSessionQueryExecute: func(info sdktrace.ExecuteDataQueryStartInfo) func(sdktrace.SessionQueryPrepareDoneInfo) {
+			end := start(tracer, info.Context, "ydb.session.execute",
+				KeySessionID.String(info.Session.ID()),
+			)
+			return func(info sdktrace.SessionQueryPrepareDoneInfo) {
+				end(info.Error)
+			}
+		},
+		OnSessionQueryStreamExecute: func(info sdktrace.SessionQueryStreamExecuteStartInfo) func(sdktrace.SessionQueryStreamExecuteDoneInfo) {
+			end := start(tracer, info.Context, "ydb.session.stream_execute",
+				KeySessionID.String(info.Session.ID()),
+			)
+			return func(info sdktrace.SessionQueryStreamExecuteDoneInfo) {
+				end(info.Error)
+			}
+		},
+		OnSessionTransactionBegin: func(info sdktrace.SessionTransactionBeginStartInfo) func(sdktrace.SessionTransactionBeginDoneInfo) {
+			end := start(tracer, info.Context, "ydb.session.begin",
+				KeySessionID.String(info.Session.ID()),
+			)
+			return func(info sdktrace.SessionTransactionBeginDoneInfo) {
+				end(info.Error)
+			}
+		},
+		OnSessionTransactionCommit: func(info sdktrace.SessionTransactionCommitStartInfo) func(sdktrace.SessionTransactionCommitDoneInfo) {
+			end := start(tracer, info.Context, "ydb.session.commit",
+				KeySessionID.String(info.Session.ID()),
+			)
+			return func(info sdktrace.SessionTransactionCommitDoneInfo) {
+				end(info.Error)
+			}
+		},
+		OnSessionTransactionRollback: func(info sdktrace.SessionTransactionRollbackStartInfo) func(sdktrace.SessionTransactionRollbackDoneInfo) {
+			end := start(tracer, info.Context, "ydb.session.rollback",
+				KeySessionID.String(info.Session.ID()),
+			)
+			return func(info sdktrace.SessionTransactionRollbackDoneInfo) {
+				end(info.Error)
+			}
+		},
+	}
+}
+
+func start(tracer oteltrace.Tracer, ctx *context.Context, name string, attrs ...attribute.KeyValue) func(error) {
+	span := startSpan(tracer, ctx, name, attrs...)
+	return func(err error) {
+		finish(span, err)
+	}
+}
+
+// startSpan starts span as child of span of *ctx and replaces *ctx with
+// context of started span.
+func startSpan(tracer oteltrace.Tracer, ctx *context.Context, name string, attrs ...attribute.KeyValue) oteltrace.Span {
+	attrs = append(attrs, keyDBSystem.String(dbSystem))
+	if attempt := ydb.RetryAttempt(*ctx); attempt > 0 {
+		attrs = append(attrs, KeyRetryAttempt.Int(attempt))
+	}
+	var span oteltrace.Span
+	*ctx, span = tracer.Start(*ctx, name,
+		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
+		oteltrace.WithAttributes(attrs...),
+	)
+	return span
+}
+
+func finish(span oteltrace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
@@ -0,0 +1,102 @@
+package otel
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"testing"
+
+	sdktrace "github.com/ydb-platform/ydb-go-sdk/v3/trace"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	otelsdk "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/ydb-platform/ydb-go-sql/internal/retry"
+	"github.com/ydb-platform/ydb-go-sql/trace"
+)
+
+type session string
+
+func (s session) ID() string     { return string(s) }
+func (s session) Status() string { return "ready" }
+
+func TestTrace(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	provider := otelsdk.NewTracerProvider(otelsdk.WithSyncer(exporter))
+	tracer := provider.Tracer("test")
+
+	var (
+		sqlTrace   = Trace(tracer)
+		tableTrace = Table(tracer)
+		fail       = errors.New("fail")
+	)
+	err := retry.Retry(context.Background(), func(ctx context.Context) error {
+		beginCtx := ctx
+		trace.TraceOnBegin(beginCtx, sqlTrace, &beginCtx, driver.IsolationLevel(0), true)("tx", nil)
+		onExec := trace.TraceOnExec(ctx, sqlTrace, &ctx, "SELECT 1", "data_query", 2, "tx", false)
+		sdktrace.TableOnSessionQueryExecute(tableTrace, &ctx, session("session"), nil, nil)(nil, false, nil, fail)
+		onExec(fail)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	spans := exporter.GetSpans()
+	if len(spans) != 3 {
+		t.Fatalf("unexpected spans count: %d", len(spans))
+	}
+	begin, execute, exec := spans[0], spans[1], spans[2]
+
+	if begin.Name != "ydb.begin" {
+		t.Fatalf("unexpected span name: %q", begin.Name)
+	}
+	assertAttributes(t, begin.Attributes,
+		keyDBSystem.String("ydb"),
+		KeyTxIsolation.String(sql.IsolationLevel(0).String()),
+		KeyTxReadOnly.Bool(true),
+		KeyTxID.String("tx"),
+		KeyRetryAttempt.Int(1),
+	)
+
+	if exec.Name != "ydb.exec" {
+		t.Fatalf("unexpected span name: %q", exec.Name)
+	}
+	if exec.Parent.IsValid() {
+		t.Fatalf("unexpected parent of exec span")
+	}
+	if exec.Status.Code != codes.Error {
+		t.Fatalf("unexpected status: %v", exec.Status)
+	}
+	assertAttributes(t, exec.Attributes,
+		keyDBSystem.String("ydb"),
+		keyDBStatement.String("SELECT 1"),
+		KeyQueryMode.String("data_query"),
+		KeyQueryArgs.Int(2),
+		KeyTxID.String("tx"),
+		KeyPrepared.Bool(false),
+		KeyRetryAttempt.Int(1),
+	)
+
+	if execute.Name != "ydb.session.execute" {
+		t.Fatalf("unexpected span name: %q", execute.Name)
+	}
+	if execute.Parent.SpanID() != exec.SpanContext.SpanID() {
+		t.Fatalf("session execute span is not a child of exec span")
+	}
+	assertAttributes(t, execute.Attributes,
+		KeySessionID.String("session"),
+	)
+}
+
+func assertAttributes(t *testing.T, act []attribute.KeyValue, exp ...attribute.KeyValue) {
+	t.Helper()
+	set := attribute.NewSet(act...)
+	for _, kv := range exp {
+		if v, ok := set.Value(kv.Key); !ok || v != kv.Value {
+			t.Errorf("unexpected attribute %s: %v; want %v", kv.Key, v.Emit(), kv.Value.Emit())
+		}
+	}
+}
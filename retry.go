@@ -0,0 +1,80 @@
+package ydb
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/ydb-platform/ydb-go-sql/internal/conn"
+	"github.com/ydb-platform/ydb-go-sql/internal/retry"
+	"github.com/ydb-platform/ydb-go-sql/internal/tx"
+)
+
+type (
+	TxOperationFunc   = tx.TxOperationFunc
+	TxDoer            = tx.TxDoer
+	ConnOperationFunc = conn.ConnOperationFunc
+	ConnDoer          = conn.ConnDoer
+	RetryOption       = retry.Option
+	RetryInfo         = retry.Info
+	Backoff           = retry.Backoff
+)
+
+// DoTx starts a transaction on db and calls f with it, retrying whole
+// transaction on retryable errors. See TxDoer.Do for details.
+func DoTx(ctx context.Context, db *sql.DB, f TxOperationFunc, opts ...RetryOption) error {
+	return tx.DoTx(ctx, db, f, opts...)
+}
+
+// Do takes a connection from db pool and calls f with it, retrying on
+// retryable errors with a fresh connection. See ConnDoer.Do for details.
+func Do(ctx context.Context, db *sql.DB, f ConnOperationFunc, opts ...RetryOption) error {
+	return conn.Do(ctx, db, f, opts...)
+}
+
+// RetryAttempt returns number of retry attempt starting from 1 within which
+// ctx is passed to operation of DoTx or Do, or 0 if ctx is not such context.
+// Contexts derived from it, e.g. passed to trace hooks of queries executed
+// within operation, report the same attempt.
+func RetryAttempt(ctx context.Context) int {
+	return retry.Attempt(ctx)
+}
+
+// WithRetryIdempotent marks operation as idempotent. By default idempotency is
+// taken from context (see retry.WithIdempotentOperation) and is false.
+// Non-idempotent operations are retried after driver.ErrBadConn only if it
+// occurred before the operation was called, e.g. on getting connection or
+// beginning transaction.
+func WithRetryIdempotent(idempotent bool) RetryOption {
+	return retry.WithIdempotent(idempotent)
+}
+
+// WithRetryMaxAttempts limits the total number of attempts. By default, and
+// with zero or negative value, attempts are bounded by context only.
+func WithRetryMaxAttempts(maxAttempts int) RetryOption {
+	return retry.WithMaxAttempts(maxAttempts)
+}
+
+// WithRetryFastBackoff replaces backoff used for errors which require fast
+// backoff and for driver.ErrBadConn. Defaults to retry.FastBackoff.
+func WithRetryFastBackoff(b Backoff) RetryOption {
+	return retry.WithFastBackoff(b)
+}
+
+// WithRetrySlowBackoff replaces backoff used for errors which require slow
+// backoff. Defaults to retry.SlowBackoff.
+func WithRetrySlowBackoff(b Backoff) RetryOption {
+	return retry.WithSlowBackoff(b)
+}
+
+// WithRetryAttemptTimeout bounds each attempt with its own timeout. By default
+// attempts are bounded by context only.
+func WithRetryAttemptTimeout(timeout time.Duration) RetryOption {
+	return retry.WithAttemptTimeout(timeout)
+}
+
+// WithOnRetry sets callback which is called after each failed attempt which
+// is going to be retried. No callback is set by default.
+func WithOnRetry(onRetry func(RetryInfo)) RetryOption {
+	return retry.WithOnRetry(onRetry)
+}
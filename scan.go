@@ -0,0 +1,49 @@
+package ydb
+
+import (
+	"database/sql"
+
+	"github.com/ydb-platform/ydb-go-sql/internal/scan"
+)
+
+// Variant is a value of YDB Variant type which could be used as destination
+// of ScanVariant.
+type Variant = scan.Variant
+
+// ScanList returns sql.Scanner which decodes List column into slice or array
+// pointed by dst. Items are decoded recursively: nested containers are
+// decoded into slices, arrays, maps and structs, Optional items into
+// pointers. Struct members are matched with struct fields by `ydb` tags.
+// NULL is decoded as zero value of *dst.
+//
+//	var ids []uint64
+//	err = row.Scan(ydb.ScanList(&ids))
+func ScanList(dst interface{}) sql.Scanner {
+	return scan.New(scan.KindList, dst)
+}
+
+// ScanTuple returns sql.Scanner which decodes Tuple column into slice or
+// array pointed by dst. See ScanList for details of decoding.
+func ScanTuple(dst interface{}) sql.Scanner {
+	return scan.New(scan.KindTuple, dst)
+}
+
+// ScanStruct returns sql.Scanner which decodes Struct column into struct or
+// map[string]T pointed by dst. Struct members without matching struct fields
+// are skipped. See ScanList for details of decoding.
+func ScanStruct(dst interface{}) sql.Scanner {
+	return scan.New(scan.KindStruct, dst)
+}
+
+// ScanDict returns sql.Scanner which decodes Dict column into map pointed by
+// dst. See ScanList for details of decoding.
+func ScanDict(dst interface{}) sql.Scanner {
+	return scan.New(scan.KindDict, dst)
+}
+
+// ScanVariant returns sql.Scanner which decodes Variant column into Variant
+// or into value of filled member pointed by dst. See ScanList for details of
+// decoding.
+func ScanVariant(dst interface{}) sql.Scanner {
+	return scan.New(scan.KindVariant, dst)
+}
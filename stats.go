@@ -0,0 +1,16 @@
+package ydb
+
+import (
+	"github.com/ydb-platform/ydb-go-sql/internal/stats"
+)
+
+type (
+	// QueryStats is a statistics of query execution passed to callback of
+	// WithStats. See its Reads, Updates, Deletes, CPUTime and Duration
+	// methods for totals over all phases.
+	QueryStats       = stats.QueryStats
+	QueryPhase       = stats.QueryPhase
+	TableAccess      = stats.TableAccess
+	OperationStats   = stats.OperationStats
+	CompilationStats = stats.CompilationStats
+)
@@ -0,0 +1,144 @@
+// Package sugar contains helpers for building YQL query text: declarations of
+// query parameters, PRAGMA TablePathPrefix and simple parameterized UPSERT,
+// REPLACE and SELECT statements.
+//
+//	var d sugar.Declaration
+//	if err := d.DeclareArgs(sql.Named("seriesData", series)); err != nil {
+//		return err
+//	}
+//	query := sugar.TablePathPrefix(database) + d.String() +
+//		sugar.Upsert("series", "seriesData", "series_id", "title")
+package sugar
+
+import (
+	"bytes"
+	"database/sql"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/types"
+
+	"github.com/ydb-platform/ydb-go-sql/internal/check"
+	"github.com/ydb-platform/ydb-go-sql/internal/x"
+)
+
+// Declaration is a builder of DECLARE statements.
+type Declaration struct {
+	buf bytes.Buffer
+}
+
+func (d *Declaration) WriteTo(dest io.Writer) (int64, error) {
+	return d.buf.WriteTo(dest)
+}
+
+func (d *Declaration) String() string {
+	return d.buf.String()
+}
+
+// Declare declares parameter name of type t.
+func (d *Declaration) Declare(name string, t types.Type) {
+	// Null value is the only way to get protobuf representation of t. It is
+	// Optional<t> which is unwrapped back to t.
+	d.declare(name, types.NullValue(t).ToYDB().GetType().GetOptionalType().GetItem())
+}
+
+// DeclareValue declares parameter name of type of v.
+func (d *Declaration) DeclareValue(name string, v types.Value) {
+	d.declare(name, v.ToYDB().GetType())
+}
+
+func (d *Declaration) declare(name string, t *Ydb.Type) {
+	d.buf.WriteString(x.Declare(name, t))
+}
+
+// DeclareArgs declares parameters of args by types of their values. Values
+// are converted into YDB types as for query arguments, so args could be the
+// same as passed to query.
+func (d *Declaration) DeclareArgs(args ...sql.NamedArg) error {
+	for _, arg := range args {
+		v, err := check.Value(arg.Value)
+		if err != nil {
+			return fmt.Errorf("%w (parameter $%s)", err, arg.Name)
+		}
+		d.DeclareValue(arg.Name, v)
+	}
+	return nil
+}
+
+// TypeString returns YQL representation of t.
+func TypeString(t *Ydb.Type) string {
+	return x.TypeString(t)
+}
+
+// TablePathPrefix returns PRAGMA TablePathPrefix statement with prefix.
+func TablePathPrefix(prefix string) string {
+	return x.TablePathPrefix(prefix)
+}
+
+// Upsert returns UPSERT statement which upserts columns of table from rows of
+// parameter param of type List<Struct<...>>.
+func Upsert(table, param string, columns ...string) string {
+	return write("UPSERT", table, param, columns)
+}
+
+// Replace returns REPLACE statement which replaces columns of table from rows
+// of parameter param of type List<Struct<...>>.
+func Replace(table, param string, columns ...string) string {
+	return write("REPLACE", table, param, columns)
+}
+
+func write(stmt, table, param string, columns []string) string {
+	var b strings.Builder
+	b.WriteString(stmt)
+	b.WriteString(" INTO ")
+	b.WriteString(Quote(table))
+	b.WriteString(" SELECT ")
+	writeColumns(&b, columns)
+	b.WriteString(" FROM AS_TABLE($")
+	b.WriteString(param)
+	b.WriteString(");\n")
+	return b.String()
+}
+
+// SelectByKey returns SELECT statement which selects columns of table row by
+// primary key columns. Key values are passed in parameters named as key
+// columns.
+func SelectByKey(table string, columns []string, key ...string) string {
+	var b strings.Builder
+	b.WriteString("SELECT ")
+	writeColumns(&b, columns)
+	b.WriteString(" FROM ")
+	b.WriteString(Quote(table))
+	for i, k := range key {
+		if i == 0 {
+			b.WriteString(" WHERE ")
+		} else {
+			b.WriteString(" AND ")
+		}
+		b.WriteString(Quote(k))
+		b.WriteString(" = $")
+		b.WriteString(k)
+	}
+	b.WriteString(";\n")
+	return b.String()
+}
+
+// Quote returns name quoted as YQL identifier.
+func Quote(name string) string {
+	return "`" + strings.NewReplacer("\\", "\\\\", "`", "\\`").Replace(name) + "`"
+}
+
+func writeColumns(b *strings.Builder, columns []string) {
+	if len(columns) == 0 {
+		b.WriteByte('*')
+		return
+	}
+	for i, c := range columns {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		b.WriteString(Quote(c))
+	}
+}
@@ -0,0 +1,108 @@
+package sugar
+
+import (
+	"database/sql"
+	"strings"
+	"testing"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/types"
+)
+
+func TestDeclaration(t *testing.T) {
+	for _, test := range []struct {
+		decl func(*Declaration)
+		exp  string
+	}{
+		{
+			decl: func(d *Declaration) {
+				d.Declare("foo", types.TypeString)
+				d.Declare("bar", types.TypeInt64)
+				d.Declare("baz", types.Struct(
+					types.StructField("foo", types.TypeString),
+					types.StructField("bar", types.TypeInt64),
+					types.StructField("baz", types.Tuple(
+						types.TypeString, types.TypeInt64,
+					)),
+				))
+			},
+			exp: strings.Join([]string{
+				"DECLARE $foo AS \"String\";",
+				"DECLARE $bar AS \"Int64\";",
+				"DECLARE $baz AS \"Struct<" +
+					"foo:String," +
+					"bar:Int64," +
+					"baz:Tuple<String,Int64>>\";",
+				"",
+			}, "\n"),
+		},
+	} {
+		t.Run("", func(t *testing.T) {
+			var d Declaration
+			test.decl(&d)
+			if act, exp := d.String(), test.exp; act != exp {
+				t.Fatalf("unexpected declaration: %q; want %q", act, exp)
+			}
+		})
+	}
+}
+
+func TestDeclareArgs(t *testing.T) {
+	type series struct {
+		ID      uint64  `ydb:"series_id"`
+		Title   string  `ydb:"title"`
+		Comment *string `ydb:"comment"`
+	}
+	var d Declaration
+	err := d.DeclareArgs(
+		sql.Named("id", uint64(1)),
+		sql.Named("series", []series{{ID: 1, Title: "IT Crowd"}}),
+		sql.Named("title", types.NullValue(types.TypeUTF8)),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	exp := strings.Join([]string{
+		"DECLARE $id AS \"Uint64\";",
+		"DECLARE $series AS \"List<Struct<series_id:Uint64,title:Utf8,comment:Optional<Utf8>>>\";",
+		"DECLARE $title AS \"Optional<Utf8>\";",
+		"",
+	}, "\n")
+	if act := d.String(); act != exp {
+		t.Fatalf("unexpected declaration: %q; want %q", act, exp)
+	}
+	if err = d.DeclareArgs(sql.Named("nil", nil)); err == nil {
+		t.Fatalf("expected error")
+	}
+}
+
+func TestStatements(t *testing.T) {
+	for _, test := range []struct {
+		act string
+		exp string
+	}{
+		{
+			act: TablePathPrefix(`/local/"foo"`),
+			exp: "PRAGMA TablePathPrefix(\"/local/\\\"foo\\\"\");\n",
+		},
+		{
+			act: Upsert("series", "seriesData", "series_id", "title"),
+			exp: "UPSERT INTO `series` SELECT `series_id`, `title` FROM AS_TABLE($seriesData);\n",
+		},
+		{
+			act: Replace("series", "seriesData", "series_id", "title"),
+			exp: "REPLACE INTO `series` SELECT `series_id`, `title` FROM AS_TABLE($seriesData);\n",
+		},
+		{
+			act: SelectByKey("episodes", []string{"title"}, "series_id", "episode_id"),
+			exp: "SELECT `title` FROM `episodes` WHERE `series_id` = $series_id AND `episode_id` = $episode_id;\n",
+		},
+		{
+			act: SelectByKey("series", nil, "series_id"),
+			exp: "SELECT * FROM `series` WHERE `series_id` = $series_id;\n",
+		},
+	} {
+		if test.act != test.exp {
+			t.Errorf("unexpected statement: %q; want %q", test.act, test.exp)
+		}
+	}
+}
@@ -4,13 +4,11 @@
 package conn
 
 import (
-	"bytes"
 	"context"
 	"database/sql"
 	"log"
 	"os"
 	"testing"
-	"text/template"
 	"time"
 
 	"github.com/ydb-platform/ydb-go-sdk/v3/table"
@@ -18,6 +16,7 @@ import (
 	"github.com/ydb-platform/ydb-go-sdk/v3/trace"
 
 	"github.com/ydb-platform/ydb-go-sql"
+	"github.com/ydb-platform/ydb-go-sql/sugar"
 )
 
 func openDB(ctx context.Context) (*sql.DB, error) {
@@ -229,7 +228,7 @@ func openDB(ctx context.Context) (*sql.DB, error) {
 //		})
 //	}
 //}
-//
+
 func TestFullWorkflow(t *testing.T) {
 	params, err := ydb.ConnectionString(os.Getenv("YDB_CONNECTION_STRING"))
 	if err != nil {
@@ -334,9 +333,7 @@ func TestFullWorkflow(t *testing.T) {
 			_ = tx.Rollback()
 		}()
 		// replace/insert over prepared query
-		stmt, err := tx.PrepareContext(ctx, render(fill, templateConfig{
-			TablePathPrefix: params.Database(),
-		}))
+		stmt, err := tx.PrepareContext(ctx, fill(params.Database()))
 		if err != nil {
 			panic(err)
 		}
@@ -357,9 +354,7 @@ func TestFullWorkflow(t *testing.T) {
 		// check explain
 		row := tx.QueryRowContext(
 			ydb.WithExplain(ctx),
-			render(fill, templateConfig{
-				TablePathPrefix: params.Database(),
-			}),
+			fill(params.Database()),
 			sql.Named("seriesData", getSeriesData()),
 			sql.Named("seasonsData", getSeasonsData()),
 			sql.Named("episodesData", getEpisodesData()),
@@ -391,25 +386,10 @@ func TestFullWorkflow(t *testing.T) {
 	{
 		rows, err := db.QueryContext(
 			ctx,
-			render(
-				template.Must(template.New("").Parse(`
-				PRAGMA TablePathPrefix("{{ .TablePathPrefix }}");
-				DECLARE $seriesID AS Uint64;
-	
-				SELECT
-					series_id,
-					title,
-					release_date
-				FROM
-					series
-				WHERE
-					series_id = $seriesID;
-			`)),
-				templateConfig{
-					TablePathPrefix: params.Database(),
-				},
-			),
-			sql.Named("seriesID", types.Uint64Value(1)),
+			sugar.TablePathPrefix(params.Database())+
+				"DECLARE $series_id AS Uint64;\n"+
+				sugar.SelectByKey("series", []string{"series_id", "title", "release_date"}, "series_id"),
+			sql.Named("series_id", types.Uint64Value(1)),
 		)
 		if err != nil {
 			panic(err)
@@ -435,20 +415,13 @@ func TestFullWorkflow(t *testing.T) {
 	{
 		rows, err := db.QueryContext(
 			ydb.WithScanQuery(ctx),
-			render(
-				template.Must(template.New("").Parse(`
-					PRAGMA TablePathPrefix("{{ .TablePathPrefix }}");
-		
-					DECLARE $series AS List<UInt64>;
-		
-					SELECT series_id, season_id, title, first_aired
-					FROM seasons
-					WHERE series_id IN $series
-				`)),
-				templateConfig{
-					TablePathPrefix: params.Database(),
-				},
-			),
+			sugar.TablePathPrefix(params.Database())+`
+				DECLARE $series AS List<UInt64>;
+
+				SELECT series_id, season_id, title, first_aired
+				FROM seasons
+				WHERE series_id IN $series
+			`,
 			sql.Named("series", types.ListValue(
 				types.Uint64Value(1),
 				types.Uint64Value(10),
@@ -476,71 +449,29 @@ func TestFullWorkflow(t *testing.T) {
 	}
 }
 
-func render(t *template.Template, data interface{}) string {
-	var buf bytes.Buffer
-	err := t.Execute(&buf, data)
-	if err != nil {
+// fill returns query which fills tables with getSeriesData, getSeasonsData
+// and getEpisodesData passed as $seriesData, $seasonsData and $episodesData.
+func fill(prefix string) string {
+	var d sugar.Declaration
+	if err := d.DeclareArgs(
+		sql.Named("seriesData", getSeriesData()),
+		sql.Named("seasonsData", getSeasonsData()),
+		sql.Named("episodesData", getEpisodesData()),
+	); err != nil {
 		panic(err)
 	}
-	return buf.String()
-}
-
-type templateConfig struct {
-	TablePathPrefix string
+	return sugar.TablePathPrefix(prefix) + d.String() +
+		sugar.Replace("series", "seriesData",
+			"series_id", "title", "series_info", "release_date", "comment",
+		) +
+		sugar.Replace("seasons", "seasonsData",
+			"series_id", "season_id", "title", "first_aired", "last_aired",
+		) +
+		sugar.Replace("episodes", "episodesData",
+			"series_id", "season_id", "episode_id", "title", "air_date",
+		)
 }
 
-var fill = template.Must(template.New("fill database").Parse(`
-	PRAGMA TablePathPrefix("{{ .TablePathPrefix }}");
-	
-	DECLARE $seriesData AS List<Struct<
-		series_id: Uint64,
-		title: Utf8,
-		series_info: Utf8,
-		release_date: Date,
-		comment: Optional<Utf8>>>;
-	
-	DECLARE $seasonsData AS List<Struct<
-		series_id: Uint64,
-		season_id: Uint64,
-		title: Utf8,
-		first_aired: Date,
-		last_aired: Date>>;
-	
-	DECLARE $episodesData AS List<Struct<
-		series_id: Uint64,
-		season_id: Uint64,
-		episode_id: Uint64,
-		title: Utf8,
-		air_date: Date>>;
-	
-	REPLACE INTO series
-	SELECT
-		series_id,
-		title,
-		series_info,
-		release_date,
-		comment
-	FROM AS_TABLE($seriesData);
-	
-	REPLACE INTO seasons
-	SELECT
-		series_id,
-		season_id,
-		title,
-		first_aired,
-		last_aired
-	FROM AS_TABLE($seasonsData);
-	
-	REPLACE INTO episodes
-	SELECT
-		series_id,
-		season_id,
-		episode_id,
-		title,
-		air_date
-	FROM AS_TABLE($episodesData);
-`))
-
 func seriesData(id uint64, released time.Time, title, info, comment string) types.Value {
 	var commentv types.Value
 	if comment == "" {
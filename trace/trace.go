@@ -0,0 +1,143 @@
+package trace
+
+//go:generate gtrace
+
+import (
+	"context"
+	"database/sql/driver"
+)
+
+type (
+	// Trace contains hooks for tracing database/sql driver activity. Hook is
+	// called on start of operation and returns function which is called on
+	// done of operation. Hooks and returned functions could be nil.
+	//
+	// Start infos contain pointer to context of operation. Hook could replace
+	// context, e.g. with context of span, and operation proceeds with it, so
+	// values of replaced context are available in traces of ydb-go-sdk.
+	// Warning: replacement is safe only within hook call.
+	//gtrace:gen
+	//gtrace:set Shortcut
+	//gtrace:set context
+	Trace struct {
+		// Connection events
+		OnConnect      func(ConnectStartInfo) func(ConnectDoneInfo)
+		OnPing         func(PingStartInfo) func(PingDoneInfo)
+		OnResetSession func(ResetSessionStartInfo) func(ResetSessionDoneInfo)
+		OnClose        func(CloseStartInfo) func(CloseDoneInfo)
+		// Query events
+		OnPrepare func(PrepareStartInfo) func(PrepareDoneInfo)
+		OnExec    func(ExecStartInfo) func(ExecDoneInfo)
+		OnQuery   func(QueryStartInfo) func(QueryDoneInfo)
+		// Transaction events
+		OnBegin    func(BeginStartInfo) func(BeginDoneInfo)
+		OnCommit   func(CommitStartInfo) func(CommitDoneInfo)
+		OnRollback func(RollbackStartInfo) func(RollbackDoneInfo)
+		// Rows events
+		OnRowsNext  func(RowsNextStartInfo) func(RowsNextDoneInfo)
+		OnRowsClose func(RowsCloseStartInfo) func(RowsCloseDoneInfo)
+	}
+)
+
+type (
+	ConnectStartInfo struct {
+		Context *context.Context
+	}
+	ConnectDoneInfo struct {
+		Error error
+	}
+	PingStartInfo struct {
+		Context *context.Context
+	}
+	PingDoneInfo struct {
+		Error error
+	}
+	ResetSessionStartInfo struct {
+		Context *context.Context
+	}
+	ResetSessionDoneInfo struct {
+		Error error
+	}
+	CloseStartInfo struct {
+		Context *context.Context
+	}
+	CloseDoneInfo struct {
+		Error error
+	}
+	PrepareStartInfo struct {
+		Context *context.Context
+		// Query is a text of query after preprocessing.
+		Query string
+		// TxID is an identifier of transaction active on connection or empty
+		// string.
+		TxID string
+	}
+	PrepareDoneInfo struct {
+		Error error
+	}
+	ExecStartInfo struct {
+		Context *context.Context
+		// Query is a text of query after preprocessing.
+		Query string
+		// Mode is a query mode: data_query, scan_query, scheme_query or
+		// explain_query.
+		Mode string
+		// ArgsCount is a number of query arguments.
+		ArgsCount int
+		// TxID is an identifier of transaction which query executes within or
+		// empty string. Read-only transactions have no identifiers.
+		TxID string
+		// Prepared is true for execution of prepared statement.
+		Prepared bool
+	}
+	ExecDoneInfo struct {
+		Error error
+	}
+	QueryStartInfo struct {
+		Context   *context.Context
+		Query     string
+		Mode      string
+		ArgsCount int
+		TxID      string
+		Prepared  bool
+	}
+	QueryDoneInfo struct {
+		Error error
+	}
+	BeginStartInfo struct {
+		Context   *context.Context
+		Isolation driver.IsolationLevel
+		ReadOnly  bool
+	}
+	BeginDoneInfo struct {
+		TxID  string
+		Error error
+	}
+	CommitStartInfo struct {
+		Context *context.Context
+		TxID    string
+	}
+	CommitDoneInfo struct {
+		Error error
+	}
+	RollbackStartInfo struct {
+		Context *context.Context
+		TxID    string
+	}
+	RollbackDoneInfo struct {
+		Error error
+	}
+	RowsNextStartInfo struct {
+		Context *context.Context
+	}
+	RowsNextDoneInfo struct {
+		// Error is io.EOF when there are no more rows.
+		Error error
+	}
+	RowsCloseStartInfo struct {
+		Context *context.Context
+	}
+	RowsCloseDoneInfo struct {
+		Error error
+	}
+)
@@ -0,0 +1,48 @@
+package trace
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestCompose(t *testing.T) {
+	var calls []string
+	hook := func(name string) Trace {
+		return Trace{
+			OnExec: func(info ExecStartInfo) func(ExecDoneInfo) {
+				calls = append(calls, name+" start "+info.Query)
+				return func(info ExecDoneInfo) {
+					calls = append(calls, name+" done "+info.Error.Error())
+				}
+			},
+			OnCommit: func(CommitStartInfo) func(CommitDoneInfo) {
+				calls = append(calls, name+" commit")
+				return nil
+			},
+		}
+	}
+
+	ctx := WithTrace(context.Background(), hook("ctx"))
+	tr := hook("a").Compose(hook("b"))
+
+	TraceOnExec(ctx, tr, &ctx, "SELECT 1", "data_query", 0, "", false)(errors.New("err"))
+	TraceOnCommit(ctx, tr, &ctx, "tx")(nil)
+	TraceOnPing(ctx, tr, &ctx)(nil)
+
+	exp := []string{
+		"a start SELECT 1",
+		"b start SELECT 1",
+		"ctx start SELECT 1",
+		"a done err",
+		"b done err",
+		"ctx done err",
+		"a commit",
+		"b commit",
+		"ctx commit",
+	}
+	if !reflect.DeepEqual(calls, exp) {
+		t.Fatalf("unexpected calls: %q; want %q", calls, exp)
+	}
+}
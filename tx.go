@@ -0,0 +1,36 @@
+package ydb
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/ydb-platform/ydb-go-sql/internal/conn"
+)
+
+// CommitContext commits active transaction of cc with ctx instead of
+// context passed to BeginTx. Transaction must be started with cc.BeginTx.
+//
+// Note that *sql.Tx still must be finished after CommitContext. Its Rollback
+// returns ErrTxCommitted which is safe to ignore:
+//
+//	tx, err := cc.BeginTx(ctx, nil)
+//	if err != nil {
+//	    // handle error
+//	}
+//	defer tx.Rollback()
+//	// Execute statements here.
+//	err = ydb.CommitContext(ctx, cc)
+func CommitContext(ctx context.Context, cc *sql.Conn) error {
+	return cc.Raw(func(dc interface{}) error {
+		return dc.(conn.Conn).CommitContext(ctx)
+	})
+}
+
+// RollbackContext rollbacks active transaction of cc with ctx instead of
+// context passed to BeginTx. If ctx is already done, rollback runs in
+// background with bounded timeout.
+func RollbackContext(ctx context.Context, cc *sql.Conn) error {
+	return cc.Raw(func(dc interface{}) error {
+		return dc.(conn.Conn).RollbackContext(ctx)
+	})
+}
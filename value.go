@@ -0,0 +1,34 @@
+package ydb
+
+import (
+	"time"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/types"
+)
+
+// Null returns null value of Optional<t> type for passing typed nil as query
+// parameter:
+//
+//	db.QueryContext(ctx, query, sql.Named("id", ydb.Null(types.TypeUint64)))
+func Null(t types.Type) types.Value {
+	return types.NullValue(t)
+}
+
+// Date returns Date value of t for passing as query parameter. By default
+// time.Time parameters are passed as Timestamp values.
+func Date(t time.Time) types.Value {
+	return types.DateValueFromTime(t)
+}
+
+// Datetime returns Datetime value of t for passing as query parameter. By
+// default time.Time parameters are passed as Timestamp values.
+func Datetime(t time.Time) types.Value {
+	return types.DatetimeValueFromTime(t)
+}
+
+// EmptyList returns empty list value of List<t> type for passing as query
+// parameter when item type could not be inferred from Go type, e.g. for
+// empty []interface{}.
+func EmptyList(t types.Type) types.Value {
+	return types.ZeroValue(types.List(t))
+}